@@ -2,6 +2,7 @@ package clif
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"reflect"
@@ -45,6 +46,14 @@ type Cli struct {
 	// PreCall is executed before the chosen command is called, if defined
 	PreCall func(c *Command) error
 
+	// HelpTemplate is an optional `text/template`, which replaces the
+	// `DescribeCliTemplate` for this cli
+	HelpTemplate string
+
+	// CommandHelpTemplate is an optional `text/template`, which replaces the
+	// `DescribeCommandTemplate` for the commands of this cli
+	CommandHelpTemplate string
+
 	// OnInterrupt, when set with `SetOnInterrupt`, is callback which is executed
 	// if user triggers interrupt (ctrl+c). If an error is returned, then the
 	// cli application will die with a non-zero status and print the error message.
@@ -88,8 +97,14 @@ func New(name, version, desc string) *Cli {
 		DefaultCommand: "list",
 	}
 
-	// add default helper commands.
-	this.Add(NewHelpCommand(), NewListCommand())
+	// keep track of terminal size changes
+	if TermResizeAutoWatch && TermIsTerminal(os.Stdout.Fd()) {
+		TermWatchResize()
+	}
+
+	// add default helper commands and options
+	this.Add(NewHelpCommand(), NewListCommand(), NewManCommand())
+	this.AddDefaultOptions(NewThemeOption(this))
 
 	// setup output & input
 	out := NewColorOutput(os.Stdout)
@@ -150,8 +165,10 @@ func (this *Cli) call(call reflect.Value, c *Command) ([]reflect.Value, error) {
 	for i := 0; i < method.NumIn(); i++ {
 		t := method.In(i)
 		s := t.String()
-		if this.Registry.Has(s) {
-			input[i] = this.Registry.Get(s)
+		if v, err := this.Registry.Resolve(t); err == nil {
+			input[i] = v
+		} else if err != ErrNotRegistered {
+			return nil, fmt.Errorf("Callback parameter of type %s for command \"%s\" could not be resolved: %s", s, c.Name, err)
 		} else if s == namedType {
 			if namedIndex > -1 {
 				return nil, fmt.Errorf("Callback has more than the one allowed input parameter of type %s, which is used to inject named parameters", namedType)
@@ -223,6 +240,17 @@ func (this *Cli) RegisterAs(n string, v interface{}) *Cli {
 	return this
 }
 
+// RegisterProvider is builder method and registers a constructor of an object
+// in registry, eg `func(cfg *Config) (*DB, error)`. See `Registry.RegisterProvider()`.
+// Panics if the provider is invalid, like invalid command definitions do. Use
+// `Registry.RegisterProvider()` to handle the error instead.
+func (this *Cli) RegisterProvider(provider interface{}) *Cli {
+	if err := this.Registry.RegisterProvider(provider); err != nil {
+		panic(err.Error())
+	}
+	return this
+}
+
 // RegisterNamed registers a parameter for injecting in a named map[string]inteface{}
 func (this *Cli) RegisterNamed(n string, v interface{}) *Cli {
 	this.Registry.Alias(fmt.Sprintf("N:%s", n), v)
@@ -256,7 +284,20 @@ func (this *Cli) RunWith(args []string) {
 	this.Heralds = make([]HeraldCallback, 0)
 	for _, cmd := range this.Commands {
 		for _, opt := range this.DefaultOptions {
-			cmd.AddOption(opt)
+			if !cmd.hasOption(opt) {
+				cmd.AddOption(opt)
+			}
+		}
+	}
+
+	// apply theme from environment, can be overwritten by option. Invalid
+	// themes are ignored, so that the default theme is used.
+	if name := os.Getenv(ThemeEnv); name != "" {
+		if theme, err := ResolveTheme(name); err != nil {
+			warn := NewColorOutput(os.Stderr)
+			warn.Printf("<warn>Ignoring theme from %s: %s<reset>\n", ThemeEnv, warn.Escape(err.Error()))
+		} else {
+			this.SetTheme(theme)
 		}
 	}
 
@@ -267,7 +308,10 @@ func (this *Cli) RunWith(args []string) {
 		// parse arguments & options
 		err := c.Parse(cargs)
 		if help := c.Option("help"); help != nil && help.Bool() {
-			this.Output().Printf(DescribeCommand(c))
+			out := this.Output()
+			pager := out.Pager()
+			io.WriteString(pager, out.Sprintf(DescribeCommand(c)))
+			pager.Close()
 			return
 		}
 		if err != nil {
@@ -349,6 +393,15 @@ func (this *Cli) SetDescription(v string) *Cli {
 	return this
 }
 
+// SetHelpTemplates is builder method and sets the templates of the help of
+// the cli and of its commands. Empty templates fall back to the default
+// templates (see `DescribeCliTemplate` and `DescribeCommandTemplate`).
+func (this *Cli) SetHelpTemplates(cliTemplate, commandTemplate string) *Cli {
+	this.HelpTemplate = cliTemplate
+	this.CommandHelpTemplate = commandTemplate
+	return this
+}
+
 // SetOutput is builder method and replaces current input
 func (this *Cli) SetInput(in Input) *Cli {
 	t := reflect.TypeOf((*Input)(nil)).Elem()
@@ -363,6 +416,12 @@ func (this *Cli) SetOutput(out Output) *Cli {
 	return this
 }
 
+// SetTheme is builder method and applies theme to the current output
+func (this *Cli) SetTheme(theme *Theme) *Cli {
+	this.Output().SetTheme(theme)
+	return this
+}
+
 // SetOnInterrupt sets callback for interrupt signal (ctrl+c)
 func (this *Cli) SetOnInterrupt(cb func() error) *Cli {
 	this.onInterrupt = cb
@@ -299,21 +299,26 @@ func TestCliConstruction(t *testing.T) {
 		app := New("My App", "1.0.0", "Testing app")
 		cb := func() {}
 
-		Convey("Two default commands exist", func() {
-			So(len(app.Commands), ShouldEqual, 2)
+		Convey("Three default commands exist", func() {
+			So(len(app.Commands), ShouldEqual, 3)
 			Convey("One is \"help\"", func() {
 				_, ok := app.Commands["help"]
 				So(ok, ShouldBeTrue)
 				Convey("Other is \"list\"", func() {
 					_, ok := app.Commands["list"]
 					So(ok, ShouldBeTrue)
+					Convey("Hidden is \"man\"", func() {
+						man, ok := app.Commands["man"]
+						So(ok, ShouldBeTrue)
+						So(man.Hidden, ShouldBeTrue)
+					})
 				})
 			})
 		})
 
 		Convey("Command constructur adds new command", func() {
 			app.New("foo", "For fooing", cb)
-			So(len(app.Commands), ShouldEqual, 3)
+			So(len(app.Commands), ShouldEqual, 4)
 			So(app.Commands["foo"], ShouldNotBeNil)
 		})
 
@@ -324,7 +329,7 @@ func TestCliConstruction(t *testing.T) {
 				NewCommand("bar", "For baring", cb),
 			}
 			app.Add(cmds...)
-			So(len(app.Commands), ShouldEqual, 4)
+			So(len(app.Commands), ShouldEqual, 5)
 			So(app.Commands["foo"], ShouldNotBeNil)
 			So(app.Commands["bar"], ShouldNotBeNil)
 		})
@@ -348,11 +353,12 @@ func TestCliDefaultCommand(t *testing.T) {
 func TestCliDefaultOptions(t *testing.T) {
 	Convey("Adding default options to cli", t, func() {
 		app := New("My App", "1.0.0", "Testing app")
-		So(len(app.DefaultOptions), ShouldEqual, 0)
+		So(len(app.DefaultOptions), ShouldEqual, 1)
+		So(app.DefaultOptions[0].Name, ShouldEqual, "theme")
 
 		Convey("Using default option creator adds option", func() {
 			app.NewDefaultOption("foo", "f", "fooing", "", false, false)
-			So(len(app.DefaultOptions), ShouldEqual, 1)
+			So(len(app.DefaultOptions), ShouldEqual, 2)
 		})
 
 		Convey("Adding default option .. adds them", func() {
@@ -360,7 +366,7 @@ func TestCliDefaultOptions(t *testing.T) {
 				NewOption("foo", "f", "fooing", "", false, false),
 				NewOption("bar", "b", "baring", "", false, false),
 			)
-			So(len(app.DefaultOptions), ShouldEqual, 2)
+			So(len(app.DefaultOptions), ShouldEqual, 3)
 		})
 
 		Convey("Cli default options are not added to command on command create", func() {
@@ -371,7 +377,12 @@ func TestCliDefaultOptions(t *testing.T) {
 
 			Convey("Default options are added in run", func() {
 				app.RunWith([]string{"bla"})
-				So(len(cmd.Options), ShouldEqual, len(DefaultOptions)+1)
+				So(len(cmd.Options), ShouldEqual, len(DefaultOptions)+2)
+
+				Convey("Default options are added once", func() {
+					app.RunWith([]string{"bla"})
+					So(len(cmd.Options), ShouldEqual, len(DefaultOptions)+2)
+				})
 			})
 		})
 	})
@@ -380,7 +391,7 @@ func TestCliDefaultOptions(t *testing.T) {
 func TestCliHeralds(t *testing.T) {
 	Convey("Command heralds are add late, in run", t, func() {
 		app := New("My App", "1.0.0", "Testing app")
-		So(len(app.Commands), ShouldEqual, 2)
+		So(len(app.Commands), ShouldEqual, 3)
 		So(len(app.Heralds), ShouldEqual, 0)
 
 		Convey("Heralding command does not add it to list", func() {
@@ -388,13 +399,13 @@ func TestCliHeralds(t *testing.T) {
 			app.Herald(func(c *Cli) *Command {
 				return NewCommand("foo", "fooing", func() { x = 2 })
 			})
-			So(len(app.Commands), ShouldEqual, 2)
+			So(len(app.Commands), ShouldEqual, 3)
 			So(len(app.Heralds), ShouldEqual, 1)
 
 			Convey("Running adds heralded commands", func() {
 				app.RunWith([]string{"foo"})
 				So(x, ShouldEqual, 2)
-				So(len(app.Commands), ShouldEqual, 3)
+				So(len(app.Commands), ShouldEqual, 4)
 				So(len(app.Heralds), ShouldEqual, 0)
 			})
 		})
@@ -422,6 +433,40 @@ func TestCliNamedRegistryParameter(t *testing.T) {
 	})
 }
 
+func TestCliRegistryProvider(t *testing.T) {
+	Convey("Injecting provided objects and interfaces", t, func() {
+		app := New("My App", "1.0.0", "Testing app")
+		app.Register(&testRegistryConfig{"foo://bar"}).
+			RegisterProvider(func(cfg *testRegistryConfig) (*testRegistryDb, error) {
+				return &testRegistryDb{cfg}, nil
+			})
+
+		Convey("Provided object is injected by interface", func() {
+			dsn := ""
+			cmd := NewCommand("foo", "Do foo", func(foo testFoo) {
+				dsn = foo.Bar()
+			})
+			_, err := app.Call(cmd)
+			So(err, ShouldBeNil)
+			So(dsn, ShouldEqual, "foo://bar")
+		})
+		Convey("Failing provider fails call", func() {
+			app.RegisterProvider(func() (*testRegistryConfig, error) {
+				return nil, fmt.Errorf("No config")
+			})
+			cmd := NewCommand("foo", "Do foo", func(db *testRegistryDb) {})
+			_, err := app.Call(cmd)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, `Callback parameter of type *clif.testRegistryDb for command "foo" could not be resolved: Provider of *clif.testRegistryConfig failed: No config`)
+		})
+		Convey("Invalid provider panics", func() {
+			So(func() {
+				app.RegisterProvider(123)
+			}, ShouldPanicWith, "Provider must be function, but is int")
+		})
+	})
+}
+
 var testCliSeparateArgs = []struct {
 	args       []string
 	expectName string
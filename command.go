@@ -38,6 +38,13 @@ type Command struct {
 	// Arguments contain all the registered arguments of the command.
 	Arguments []*Argument
 
+	// Hidden commands are not listed in the help output
+	Hidden bool
+
+	// Examples contain usage examples, which are shown in help output, man
+	// pages and docs
+	Examples []*Example
+
 	// Call holds reflections of the callback.
 	Call reflect.Value
 
@@ -91,6 +98,19 @@ func (this *Command) SetDescription(desc string) *Command {
 	return this
 }
 
+// AddExample is builder method adding a usage example. The command line
+// contains arguments and options, without program and command name.
+func (this *Command) AddExample(cmdline, explanation string) *Command {
+	this.Examples = append(this.Examples, NewExample(cmdline, explanation))
+	return this
+}
+
+// SetHidden is builder method setting whether command is listed in help or not
+func (this *Command) SetHidden(v bool) *Command {
+	this.Hidden = v
+	return this
+}
+
 func (this *Command) SetPreCall(call CallMethod) *Command {
 	ref := reflect.ValueOf(call)
 	if ref.Kind() != reflect.Func {
@@ -271,6 +291,17 @@ func (this *Command) AddOption(v *Option) *Command {
 	return this
 }
 
+// hasOption returns whether the option itself (not another option of the same
+// name) has been added already, eg as default option in a previous run
+func (this *Command) hasOption(v *Option) bool {
+	for _, o := range this.Options {
+		if o == v {
+			return true
+		}
+	}
+	return false
+}
+
 // Argument provides access to registered, named arguments.
 func (this *Command) Argument(name string) *Argument {
 	for _, a := range this.Arguments {
@@ -5,7 +5,6 @@ import (
 	"os"
 	"regexp"
 	"strings"
-	"unicode/utf8"
 	"io"
 	"path/filepath"
 	"time"
@@ -19,10 +18,70 @@ var (
 	rxControlCharacters = regexp.MustCompile(`\033\[[\d;]+m`)
 )
 
-// StringLength returns the length of an UTF8 string without any control characters
+// StringLength returns the display width of an UTF8 string without any control
+// characters. Wide runes (eg CJK or emoji) count double, combining marks and
+// runes joined by zero-width joiners not at all (see `RuneWidth()`).
 func StringLength(str string) int {
 	str = rxControlCharacters.ReplaceAllString(str, "")
-	return utf8.RuneCountInString(str)
+	length := 0
+	previous := rune(0)
+	for _, r := range str {
+		length += runeWidthAfter(r, previous)
+		previous = r
+	}
+	return length
+}
+
+// TruncateString cuts a string, which may contain control characters, to the
+// given display width (without control characters) and appends the ellipsis,
+// if the string is longer. Open control characters are closed at the end.
+// If the length is not positive, an empty string is returned.
+func TruncateString(str string, length int, ellipsis string) string {
+	if length <= 0 {
+		return ""
+	} else if StringLength(str) <= length {
+		return str
+	}
+	ellipsisLength := StringLength(ellipsis)
+	if length <= ellipsisLength {
+		return TruncateString(ellipsis, length, "")
+	}
+
+	limit := length - ellipsisLength
+	out := ""
+	count := 0
+	full := false
+	previous := rune(0)
+	hasControl := false
+	pos := 0
+	add := func(s string) {
+		for _, r := range s {
+			width := runeWidthAfter(r, previous)
+			if count+width > limit {
+				full = true
+				return
+			}
+			out += string(r)
+			count += width
+			previous = r
+		}
+	}
+	for _, loc := range rxControlCharacters.FindAllStringIndex(str, -1) {
+		if add(str[pos:loc[0]]); full || count == limit {
+			break
+		}
+		out += str[loc[0]:loc[1]]
+		hasControl = true
+		pos = loc[1]
+	}
+	if !full && count < limit {
+		add(str[pos:])
+	}
+	out += ellipsis
+	if hasControl {
+		out += "\033[0m"
+	}
+	return out
 }
 
 // SplitFormattedString splits formatted string into multiple lines while making
@@ -1,19 +1,24 @@
 package clif
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+)
 
 // NewHelpCommand returns the default help command
 func NewHelpCommand() *Command {
 	return NewCommand("help", "Show this help", func(o *Command, out Output) error {
+		pager := out.Pager()
+		defer pager.Close()
 		if n := o.Argument("command").String(); n != "" {
 			if cmd, ok := o.Cli.Commands[n]; ok {
-				out.Printf(DescribeCommand(cmd))
+				io.WriteString(pager, out.Sprintf(DescribeCommand(cmd)))
 			} else {
-				out.Printf(DescribeCli(o.Cli))
+				io.WriteString(pager, out.Sprintf(DescribeCli(o.Cli)))
 				return fmt.Errorf("Unknown command \"%s\"", n)
 			}
 		} else {
-			out.Printf(DescribeCommand(o))
+			io.WriteString(pager, out.Sprintf(DescribeCommand(o)))
 		}
 		return nil
 	}).NewArgument("command", "Command to show help for", "", false, false)
@@ -21,7 +26,9 @@ func NewHelpCommand() *Command {
 
 // NewListCommand returns the default help command
 func NewListCommand() *Command {
-	return NewCommand("list", "List all available commands", func(c *Cli, Command, out Output) {
-		out.Printf(DescribeCli(c))
+	return NewCommand("list", "List all available commands", func(c *Cli, out Output) {
+		pager := out.Pager()
+		defer pager.Close()
+		io.WriteString(pager, out.Sprintf(DescribeCli(c)))
 	})
 }
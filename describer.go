@@ -1,167 +1,218 @@
 package clif
 
 import (
+	"bytes"
 	"fmt"
-	"os"
-	"path/filepath"
-	"sort"
 	"strings"
+	"text/template"
+)
+
+var (
+
+	// DescribeMinColumnWidth is the min width of a column in help output, below
+	// which text is not wrapped anymore (see `DescribeColumn`)
+	DescribeMinColumnWidth = 20
+
+	// DescribeCliTemplate is the default `text/template` of the help of a cli,
+	// which `DescribeCli` uses. It is executed with a `*CliDescription`. The
+	// functions in `DescribeFuncs` are available.
+	DescribeCliTemplate = `<headline>{{ .Name }}<reset>{{ if .Version }} <debug>({{ .Version }})<reset>{{ end }}
+{{ if .Description }}{{ column (print "<info>" .Description "<reset>") 0 }}
+
+{{ end }}<subline>Usage:<reset>
+  {{ .Program }} {{ .Usage }}
+
+<subline>Available commands:<reset>
+{{ $width := commandWidth .Commands }}{{ $indent := add $width 4 }}{{ range .Groups }}{{ if .Prefix }} <subline>{{ .Prefix }}<reset>
+{{ end }}{{ range .Commands }}  <info>{{ pad .Name $width }}<reset>  {{ column .Usage $indent }}
+{{ end }}{{ end }}`
+
+	// DescribeCommandTemplate is the default `text/template` of the help of a
+	// command, which `DescribeCommand` uses. It is executed with a
+	// `*CommandDescription`. The functions in `DescribeFuncs` are available.
+	DescribeCommandTemplate = `Command: <headline>{{ .Name }}<reset>
+{{ if .Description }}{{ markdown .Description }}
+
+{{ else if .Usage }}{{ column (print "<info>" .Usage "<reset>") 0 }}
+
+{{ end }}<subline>Usage:<reset>
+  {{ .Synopsis }}
+
+{{ if .Arguments }}<subline>Arguments:<reset>
+{{ $width := labelWidth .Arguments }}{{ $indent := add $width 4 }}{{ range .Arguments }}  <info>{{ pad .Label $width }}<reset>  {{ column (usage .) $indent }}{{ if and .Description (ne .Description .Usage) }}
+{{ indent $indent }}{{ column .Description $indent }}{{ end }}
+{{ end }}
+{{ end }}{{ if .Options }}<subline>Options:<reset>
+{{ $width := labelWidth .Options }}{{ $indent := add $width 4 }}{{ range .Options }}  <info>{{ pad .Label $width }}<reset>  {{ column (usage .) $indent }}{{ if and .Description (ne .Description .Usage) }}
+{{ indent $indent }}{{ column .Description $indent }}{{ end }}
+{{ end }}
+{{ end }}{{ if .Examples }}<subline>Examples:<reset>
+{{ range .Examples }}  <info>$ {{ escape .Cmdline }}<reset>
+{{ with .Explanation }}    {{ column . 4 }}
+{{ end }}{{ end }}
+{{ end }}`
+
+	// DescribeFuncs are the functions available in help templates:
+	//
+	//	pad          pads string with spaces to the given length, eg {{ pad .Name 10 }}
+	//	commandWidth returns the max name length of a list of commands
+	//	labelWidth   returns the max label length of a list of parameters
+	//	info         returns comma separated metadata (mult, req, env, default) of a parameter
+	//	usage        returns usage of a parameter, followed by its metadata in brackets
+	//	column       wraps text to the terminal width, with a hanging indent of the given length
+	//	indent       returns the given number of spaces
+	//	add          adds two numbers
+	//	markdown     renders Markdown into style tokens (see `RenderMarkdown`)
+	//	escape       escapes style tokens, eg in example command lines
+	DescribeFuncs = template.FuncMap{
+		"pad": func(s string, width int) string {
+			return fmt.Sprintf("%-"+fmt.Sprintf("%d", width)+"s", s)
+		},
+		"commandWidth": func(commands []*CommandDescription) int {
+			max := 0
+			for _, cmd := range commands {
+				if l := len(cmd.Name); l > max {
+					max = l
+				}
+			}
+			return max
+		},
+		"labelWidth": func(params []*ParameterDescription) int {
+			max := 0
+			for _, p := range params {
+				if l := len(p.Label); l > max {
+					max = l
+				}
+			}
+			return max
+		},
+		"info": describeParameterInfo,
+		"usage": func(p *ParameterDescription) string {
+			if info := describeParameterInfo(p); info != "" {
+				return p.Usage + " (" + info + ")"
+			}
+			return p.Usage
+		},
+		"column": DescribeColumn,
+		"indent": func(n int) string {
+			return strings.Repeat(" ", n)
+		},
+		"add": func(a, b int) int {
+			return a + b
+		},
+		"markdown": func(s string) string {
+			return RenderMarkdown(s, termWidthCurrent())
+		},
+		"escape": func(s string) string {
+			return new(DefaultFormatter).Escape(s)
+		},
+	}
 )
 
 // DescribeCli command implements the string rendering of a cli which help uses.
-// Can be overwritten at users discretion.
+// It renders the `HelpTemplate` of the cli or, if not set, the
+// `DescribeCliTemplate`. Can be overwritten at users discretion.
 var DescribeCli = func(c *Cli) string {
+	tpl := DescribeCliTemplate
+	if c.HelpTemplate != "" {
+		tpl = c.HelpTemplate
+	}
+	return RenderDescribeTemplate(tpl, NewCliDescription(c))
+}
 
-	// name + version
-	line := "<headline>" + c.Name + "<reset>"
-	if c.Version != "" {
-		line += " <debug>(" + c.Version + ")<reset>"
+// DescribeCommand implements the string rendering of a command which help uses.
+// It renders the `CommandHelpTemplate` of the cli of the command or, if not
+// set, the `DescribeCommandTemplate`. Can be overwritten at users discretion.
+var DescribeCommand = func(c *Command) string {
+	tpl := DescribeCommandTemplate
+	if c.Cli != nil && c.Cli.CommandHelpTemplate != "" {
+		tpl = c.Cli.CommandHelpTemplate
 	}
-	lines := []string{line}
+	return RenderDescribeTemplate(tpl, NewCommandDescription(c))
+}
 
-	// description
-	if c.Description != "" {
-		lines = append(lines, "<info>"+c.Description+"<reset>\n")
+// RenderDescribeTemplate executes a help template with the given data, which
+// usually is a `*CliDescription` or a `*CommandDescription`. If the template
+// fails, the error message is returned, formatted with the error style.
+func RenderDescribeTemplate(tpl string, data interface{}) string {
+	t, err := template.New("describe").Funcs(DescribeFuncs).Parse(tpl)
+	if err != nil {
+		return "<error>Failed to parse help template: " + err.Error() + "<reset>\n"
 	}
+	buf := bytes.NewBuffer(nil)
+	if err := t.Execute(buf, data); err != nil {
+		return "<error>Failed to render help template: " + err.Error() + "<reset>\n"
+	}
+	return buf.String()
+}
 
-	// usage
-	prog := filepath.Base(os.Args[0])
-	lines = append(lines, fmt.Sprintf("<subline>Usage:<reset>\n  %s command [arg ..] [--opt val ..]\n", prog))
-
-	// commands
-	lines = append(lines, "<subline>Available commands:<reset>")
-	max := 0
-	ordered := make(map[string][]*Command)
-	prefices := make([]string, 0)
-	for _, cmd := range c.Commands {
-		if l := len(cmd.Name); l > max {
-			max = l
-		}
-		prefix := ""
-		if i := strings.Index(cmd.Name, ":"); i > -1 {
-			prefix = cmd.Name[0:i]
-		}
-		if ordered[prefix] == nil {
-			prefices = append(prefices, prefix)
-			ordered[prefix] = make([]*Command, 0)
-		}
-		ordered[prefix] = append(ordered[prefix], cmd)
+// DescribeColumn wraps text, which may contain style tokens, to the width of
+// the terminal (`TermWidthCurrent`) minus the given indent. All but the first
+// line are prefixed with indent spaces, so that the text is aligned in a column
+// which starts at indent. Text is not wrapped if the remaining width is less
+// than `DescribeMinColumnWidth`.
+func DescribeColumn(s string, indent int) string {
+	if limit := termWidthCurrent() - indent; limit >= DescribeMinColumnWidth {
+		wrapper := NewWrapper(uint(limit))
+		wrapper.KeepEmptyLines = true
+		s = wrapper.WrapTokens(s)
 	}
-	sort.Strings(prefices)
-	for _, prefix := range prefices {
-		if prefix != "" {
-			lines = append(lines, fmt.Sprintf(" <subline>%s<reset>", prefix))
-		}
-		sort.Sort(CommandsSort(ordered[prefix]))
-		for _, cmd := range ordered[prefix] {
-			lines = append(lines, fmt.Sprintf("  <info>%-"+fmt.Sprintf("%d", max)+"s<reset>  %s", cmd.Name, cmd.Usage))
+	prefix := strings.Repeat(" ", indent)
+	lines := strings.Split(s, "\n")
+	for idx := 1; idx < len(lines); idx++ {
+		if lines[idx] != "" {
+			lines[idx] = prefix + lines[idx]
 		}
 	}
-
-	return strings.Join(lines, "\n") + "\n"
+	return strings.Join(lines, "\n")
 }
 
-
-// DescribeCommand implements the string rendering of a command which help uses.
-// Can be overwritten at users discretion.
-var DescribeCommand = func(c *Command) string {
-	lines := []string{"Command: <headline>" + c.Name + "<reset>"}
-
-	if c.Description != "" {
-		lines = append(lines, []string{"<info>" + c.Description + "<reset>", ""}...)
-	} else if c.Usage != "" {
-		lines = append(lines, []string{"<info>" + c.Usage + "<reset>", ""}...)
+func describeParameterInfo(p *ParameterDescription) string {
+	info := []string{}
+	if p.Multiple {
+		info = append(info, `<debug>mult<reset>`)
+	}
+	if p.Required {
+		info = append(info, `<important>req<reset>`)
+	}
+	if p.Env != "" {
+		info = append(info, fmt.Sprintf(`env: <debug>%s<reset>`, p.Env))
 	}
+	if p.Default != "" {
+		info = append(info, fmt.Sprintf(`default: <debug>"%s"<reset>`, p.Default))
+	}
+	return strings.Join(info, ", ")
+}
 
-	lines = append(lines, "<subline>Usage:<reset>")
+// CommandUsage returns the short usage line of a command, containing all
+// arguments and options, eg `foo bar [baz ...] [--help|-h] --boing|-b val`
+func CommandUsage(c *Command) string {
 	usage := []string{c.Name}
-	args := make([][]string, 0)
-	argMax := 0
-	opts := make([][]string, 0)
-	optMax := 0
 	for _, p := range c.Arguments {
-		var short string
-		usg := p.Usage
-		short = p.Name
-		usgInfo := []string{}
+		short := p.Name
 		if p.Multiple {
-			short = short + " ..."
-			usgInfo = append(usgInfo, `<debug>mult<reset>`)
-		}
-		if p.Required {
-			usgInfo = append(usgInfo, `<important>req<reset>`)
-		} else {
-			short = fmt.Sprintf("[%s]", short)
+			short += " ..."
 		}
-		if p.Env != "" {
-			usgInfo = append(usgInfo, fmt.Sprintf(`env: <debug>%s<reset>`, p.Env))
-		}
-		if p.Default != "" {
-			usgInfo = append(usgInfo, fmt.Sprintf(`default: <debug>"%s"<reset>`, p.Default))
-		}
-		if l := len(p.Name); l > argMax {
-			argMax = l
+		if !p.Required {
+			short = "[" + short + "]"
 		}
 		usage = append(usage, short)
-		if len(usgInfo) > 0 {
-			usg += " ("+ strings.Join(usgInfo, ", ")+ ")"
-		}
-		args = append(args, []string{p.Name, usg})
 	}
-
 	for _, p := range c.Options {
-		short := fmt.Sprintf("--%s", p.Name)
+		short := "--" + p.Name
 		if p.Alias != "" {
 			short += "|-" + p.Alias
 		}
 		if !p.Flag {
 			short += " val"
 		}
-		long := short
-		usg := p.Usage
-		usgInfo := []string{}
 		if p.Multiple {
-			short = short + " ..."
-			usgInfo = append(usgInfo, `<debug>mult<reset>`)
+			short += " ..."
 		}
 		if !p.Required {
 			short = "[" + short + "]"
-		} else {
-			usgInfo = append(usgInfo, `<important>req<reset>`)
-		}
-		if p.Env != "" {
-			usgInfo = append(usgInfo, fmt.Sprintf(`env: <debug>%s<reset>`, p.Env))
-		}
-		if p.Default != "" {
-			usgInfo = append(usgInfo, fmt.Sprintf(`default: <debug>"%s"<reset>`, p.Default))
-		}
-		if l := len(long); l > optMax {
-			optMax = l
 		}
 		usage = append(usage, short)
-		if len(usgInfo) > 0 {
-			usg += " ("+ strings.Join(usgInfo, ", ")+ ")"
-		}
-		opts = append(opts, []string{long, usg})
-	}
-	lines = append(lines, "  "+strings.Join(usage, " "))
-	lines = append(lines, "")
-
-	if len(args) > 0 {
-		lines = append(lines, "<subline>Arguments:<reset>")
-		for _, l := range args {
-			lines = append(lines, fmt.Sprintf("  <info>%-"+fmt.Sprintf("%d", argMax)+"s<reset>  %s", l[0], l[1]))
-		}
-		lines = append(lines, "")
-	}
-
-	if len(opts) > 0 {
-		lines = append(lines, "<subline>Options:<reset>")
-		for _, l := range opts {
-			lines = append(lines, fmt.Sprintf("  <info>%-"+fmt.Sprintf("%d", optMax)+"s<reset>  %s", l[0], l[1]))
-		}
-		lines = append(lines, "")
 	}
-
-	return strings.Join(lines, "\n") + "\n"
-}
\ No newline at end of file
+	return strings.Join(usage, " ")
+}
@@ -19,7 +19,7 @@ func TestDescribeCommand(t *testing.T) {
 
 		s := DescribeCommand(c)
 		expect := `Command: <headline>foo<reset>
-<info>It does really, really foo<reset>
+It does really, really foo
 
 <subline>Usage:<reset>
   foo bar [baz ...] [--help|-h] --boing|-b val [--zoing|-z ...]
@@ -72,3 +72,97 @@ func TestDescriberCli(t *testing.T) {
 		So(s, ShouldEqual, expect)
 	})
 }
+
+func TestDescribeTemplates(t *testing.T) {
+	Convey("Custom help templates of cli", t, func() {
+		c := New("cli", "1.0.1", "My CLI").
+			New("foo", "It does foo", func() {}).
+			SetHelpTemplates(
+				"{{ .Name }}:{{ range .Commands }} {{ .Name }}{{ end }}",
+				"{{ .Cli.Name }} {{ .Name }}{{ range .Options }} {{ .Label }}{{ with info . }} ({{ . }}){{ end }}{{ end }}",
+			)
+		c.Commands["foo"].NewOption("bar", "b", "The bar", "baz", false, false)
+
+		So(DescribeCli(c), ShouldEqual, "cli: foo help list")
+		So(DescribeCommand(c.Commands["foo"]), ShouldEqual, `cli foo --help|-h --bar|-b val (default: <debug>"baz"<reset>)`)
+
+		Convey("Falls back to default templates", func() {
+			c.SetHelpTemplates("", "")
+			So(DescribeCli(c), ShouldStartWith, "<headline>cli<reset>")
+			So(DescribeCommand(c.Commands["foo"]), ShouldStartWith, "Command: <headline>foo<reset>")
+		})
+
+		Convey("Invalid templates render error", func() {
+			c.SetHelpTemplates("{{ .Name ", "{{ .NotThere }}")
+			So(DescribeCli(c), ShouldStartWith, "<error>Failed to parse help template:")
+			So(DescribeCommand(c.Commands["foo"]), ShouldStartWith, "<error>Failed to render help template:")
+		})
+	})
+}
+
+func TestDescribeCommandExamples(t *testing.T) {
+	Convey("Description of command with examples", t, func() {
+		c := NewCommand("foo", "It does foo", func() {}).
+			NewArgument("bar", "The bar", "", false, false).
+			AddExample("<bar>", "Do foo with bar").
+			AddExample("", "")
+
+		s := DescribeCommand(c)
+		expect := `Command: <headline>foo<reset>
+<info>It does foo<reset>
+
+<subline>Usage:<reset>
+  foo [bar] [--help|-h]
+
+<subline>Arguments:<reset>
+  <info>bar<reset>  The bar
+
+<subline>Options:<reset>
+  <info>--help|-h<reset>  Display this help message
+
+<subline>Examples:<reset>
+  <info>$ clif.test foo \<bar><reset>
+    Do foo with bar
+  <info>$ clif.test foo<reset>
+
+`
+		So(s, ShouldEqual, expect)
+	})
+}
+
+func TestDescribeCommandWrapped(t *testing.T) {
+	Convey("Description of command is wrapped to terminal width", t, func() {
+		orig := TermWidthCurrent
+		TermWidthCurrent = 40
+		defer func() {
+			TermWidthCurrent = orig
+		}()
+
+		c := NewCommand("foo", "It does foo", func() {}).
+			NewOption("boing", "b", "The boing, which is used for many things", "", true, false)
+		c.Option("boing").
+			SetEnv("THE_BOING").
+			SetDescription("A lengthy elaboration of the boing.\n\nAnd more")
+
+		s := DescribeCommand(c)
+		expect := `Command: <headline>foo<reset>
+<info>It does foo<reset>
+
+<subline>Usage:<reset>
+  foo [--help|-h] --boing|-b val
+
+<subline>Options:<reset>
+  <info>--help|-h     <reset>  Display this help
+                  message
+  <info>--boing|-b val<reset>  The boing, which is
+                  used for many things
+                  (<important>req<reset>, env: <debug>THE_BOING<reset>)
+                  A lengthy elaboration
+                  of the boing.
+
+                  And more
+
+`
+		So(s, ShouldEqual, expect)
+	})
+}
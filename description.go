@@ -0,0 +1,230 @@
+package clif
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CliDescription is the data model of a cli, as it is used to render help,
+// man pages or documentation
+type CliDescription struct {
+
+	// Name is the name of the application
+	Name string
+
+	// Version is the version of the application
+	Version string
+
+	// Description of the application
+	Description string
+
+	// Program is the name of the executable, eg "my-app"
+	Program string
+
+	// Usage is the generic usage line of the application, without the program
+	Usage string
+
+	// Commands contains all not hidden commands, sorted by name
+	Commands []*CommandDescription
+
+	// Groups contains all not hidden commands, grouped by their prefix (eg
+	// "foo" for "foo:bar"). Commands without prefix are in the first group,
+	// which has an empty prefix.
+	Groups []*CommandGroupDescription
+}
+
+// CommandGroupDescription is a group of commands with the same prefix
+type CommandGroupDescription struct {
+
+	// Prefix is the common prefix of the commands, eg "foo" for "foo:bar"
+	Prefix string
+
+	// Commands of the group, sorted by name
+	Commands []*CommandDescription
+}
+
+// CommandDescription is the data model of a command
+type CommandDescription struct {
+
+	// Cli describes the application the command belongs to (without commands)
+	Cli *CliDescription
+
+	// Name is the call-name of the command
+	Name string
+
+	// Usage is the shorthand description of the command
+	Usage string
+
+	// Description is the long elaboration of the command
+	Description string
+
+	// Synopsis is the usage line of the command (see `CommandUsage()`)
+	Synopsis string
+
+	// Arguments of the command, in the order of definition
+	Arguments []*ParameterDescription
+
+	// Options of the command, in the order of definition
+	Options []*ParameterDescription
+
+	// Examples of the command, in the order of definition
+	Examples []*ExampleDescription
+}
+
+// ExampleDescription is the data model of a usage example of a command
+type ExampleDescription struct {
+
+	// Cmdline is the full command line, including program and command name
+	Cmdline string
+
+	// Explanation describes what the example does
+	Explanation string
+}
+
+// ParameterDescription is the data model of an argument or an option
+type ParameterDescription struct {
+
+	// Name of the parameter
+	Name string
+
+	// Alias of the option. Always empty for arguments.
+	Alias string
+
+	// Label is how the parameter is used on the command line, eg "name" for an
+	// argument or "--name|-n val" for an option
+	Label string
+
+	// Usage is the short description of the parameter
+	Usage string
+
+	// Description is the long elaboration of the parameter
+	Description string
+
+	// Env is the name of the environment variable of the parameter, if any
+	Env string
+
+	// Default is the default value of the parameter, if any
+	Default string
+
+	// Required is true if the parameter must be provided
+	Required bool
+
+	// Multiple is true if the parameter accepts multiple values
+	Multiple bool
+
+	// Flag is true if the parameter is an option which is a flag
+	Flag bool
+}
+
+// NewCliDescription returns the data model of a cli
+func NewCliDescription(c *Cli) *CliDescription {
+	desc := newCliDescriptionHeader(c)
+	commands := make([]*Command, 0)
+	for _, cmd := range c.Commands {
+		if !cmd.Hidden {
+			commands = append(commands, cmd)
+		}
+	}
+	sort.Sort(CommandsSort(commands))
+
+	groups := make(map[string]*CommandGroupDescription)
+	for _, cmd := range commands {
+		cmdDesc := NewCommandDescription(cmd)
+		cmdDesc.Cli = desc
+		desc.Commands = append(desc.Commands, cmdDesc)
+
+		prefix := ""
+		if i := strings.Index(cmd.Name, ":"); i > -1 {
+			prefix = cmd.Name[0:i]
+		}
+		if groups[prefix] == nil {
+			groups[prefix] = &CommandGroupDescription{Prefix: prefix}
+			desc.Groups = append(desc.Groups, groups[prefix])
+		}
+		groups[prefix].Commands = append(groups[prefix].Commands, cmdDesc)
+	}
+
+	sort.Sort(commandGroupDescriptionsSort(desc.Groups))
+
+	return desc
+}
+
+// NewCommandDescription returns the data model of a command
+func NewCommandDescription(c *Command) *CommandDescription {
+	desc := &CommandDescription{
+		Name:        c.Name,
+		Usage:       c.Usage,
+		Description: c.Description,
+		Synopsis:    CommandUsage(c),
+		Arguments:   make([]*ParameterDescription, len(c.Arguments)),
+		Options:     make([]*ParameterDescription, len(c.Options)),
+		Examples:    make([]*ExampleDescription, len(c.Examples)),
+	}
+	if c.Cli != nil {
+		desc.Cli = newCliDescriptionHeader(c.Cli)
+	}
+	for idx, a := range c.Arguments {
+		desc.Arguments[idx] = newParameterDescription(&a.parameter, a.Name)
+	}
+	for idx, o := range c.Options {
+		label := "--" + o.Name
+		if o.Alias != "" {
+			label += "|-" + o.Alias
+		}
+		if !o.Flag {
+			label += " val"
+		}
+		desc.Options[idx] = newParameterDescription(&o.parameter, label)
+		desc.Options[idx].Alias = o.Alias
+		desc.Options[idx].Flag = o.Flag
+	}
+	for idx, e := range c.Examples {
+		cmdline := strings.TrimSpace(filepath.Base(os.Args[0]) + " " + c.Name + " " + e.Cmdline)
+		desc.Examples[idx] = &ExampleDescription{
+			Cmdline:     cmdline,
+			Explanation: e.Explanation,
+		}
+	}
+	return desc
+}
+
+func newCliDescriptionHeader(c *Cli) *CliDescription {
+	return &CliDescription{
+		Name:        c.Name,
+		Version:     c.Version,
+		Description: c.Description,
+		Program:     filepath.Base(os.Args[0]),
+		Usage:       "command [arg ..] [--opt val ..]",
+		Commands:    make([]*CommandDescription, 0),
+		Groups:      make([]*CommandGroupDescription, 0),
+	}
+}
+
+func newParameterDescription(p *parameter, label string) *ParameterDescription {
+	return &ParameterDescription{
+		Name:        p.Name,
+		Label:       label,
+		Usage:       p.Usage,
+		Description: p.Description,
+		Env:         p.Env,
+		Default:     p.Default,
+		Required:    p.Required,
+		Multiple:    p.Multiple,
+	}
+}
+
+type commandGroupDescriptionsSort []*CommandGroupDescription
+
+func (this commandGroupDescriptionsSort) Len() int {
+	return len(this)
+}
+
+func (this commandGroupDescriptionsSort) Less(i, j int) bool {
+	return this[i].Prefix < this[j].Prefix
+}
+
+func (this commandGroupDescriptionsSort) Swap(i, j int) {
+	this[i], this[j] = this[j], this[i]
+}
@@ -0,0 +1,67 @@
+package clif
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestCliDescription(t *testing.T) {
+	Convey("Data model of cli", t, func() {
+		c := New("cli", "1.0.1", "My CLI").
+			New("foo", "It does foo", func() {}).
+			New("zzz:uno", "A sub of zzz", func() {}).
+			New("bla:due", "A sub of bla", func() {}).
+			New("bla:uno", "A sub of bla", func() {})
+		c.Commands["foo"].SetHidden(true)
+
+		desc := NewCliDescription(c)
+		So(desc.Name, ShouldEqual, "cli")
+		So(desc.Version, ShouldEqual, "1.0.1")
+		So(desc.Program, ShouldEqual, "clif.test")
+
+		names := []string{}
+		for _, cmd := range desc.Commands {
+			names = append(names, cmd.Name)
+			So(cmd.Cli, ShouldEqual, desc)
+		}
+		So(names, ShouldResemble, []string{"bla:due", "bla:uno", "help", "list", "zzz:uno"})
+
+		groups := []string{}
+		for _, group := range desc.Groups {
+			groups = append(groups, group.Prefix)
+		}
+		So(groups, ShouldResemble, []string{"", "bla", "zzz"})
+		So(len(desc.Groups[1].Commands), ShouldEqual, 2)
+	})
+}
+
+func TestCommandDescription(t *testing.T) {
+	Convey("Data model of command", t, func() {
+		c := NewCommand("foo", "It does foo", func() {}).
+			NewArgument("bar", "The bar", "", true, true).
+			NewOption("boing", "b", "The boing!", "x", false, false).
+			NewFlag("zoing", "z", "The zoing!", false)
+		c.Option("boing").SetEnv("BOING")
+
+		desc := NewCommandDescription(c)
+		So(desc.Cli, ShouldBeNil)
+		So(desc.Synopsis, ShouldEqual, CommandUsage(c))
+		So(*desc.Arguments[0], ShouldResemble, ParameterDescription{
+			Name:     "bar",
+			Label:    "bar",
+			Usage:    "The bar",
+			Required: true,
+			Multiple: true,
+		})
+		So(*desc.Options[1], ShouldResemble, ParameterDescription{
+			Name:    "boing",
+			Alias:   "b",
+			Label:   "--boing|-b val",
+			Usage:   "The boing!",
+			Env:     "BOING",
+			Default: "x",
+		})
+		So(desc.Options[2].Label, ShouldEqual, "--zoing|-z")
+		So(desc.Options[2].Flag, ShouldBeTrue)
+	})
+}
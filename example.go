@@ -0,0 +1,114 @@
+package clif
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Example is a documented usage example of a command
+type Example struct {
+
+	// Cmdline contains the arguments and options of the example, without
+	// program and command name, eg `bar --baz "some value"`
+	Cmdline string
+
+	// Explanation describes what the example does
+	Explanation string
+}
+
+// NewExample constructs new example
+func NewExample(cmdline, explanation string) *Example {
+	return &Example{
+		Cmdline:     cmdline,
+		Explanation: explanation,
+	}
+}
+
+// Args returns the command line of the example split into arguments
+func (this *Example) Args() ([]string, error) {
+	return SplitCommandLine(this.Cmdline)
+}
+
+// ParseExamples parses the command line of each example of the command with
+// `Parse()` and returns the first error, so that examples can be checked in
+// tests. Values of arguments and options are restored afterwards. Mind that
+// default options of the cli are only known after they have been added to the
+// command, which happens in `Run()`.
+func (this *Command) ParseExamples() error {
+	params := make([]*parameter, 0, len(this.Arguments)+len(this.Options))
+	for _, a := range this.Arguments {
+		params = append(params, &a.parameter)
+	}
+	for _, o := range this.Options {
+		params = append(params, &o.parameter)
+	}
+	values := make([][]string, len(params))
+	for idx, p := range params {
+		values[idx] = p.Values
+	}
+	defer func() {
+		for idx, p := range params {
+			p.Values = values[idx]
+		}
+	}()
+
+	for _, example := range this.Examples {
+		args, err := example.Args()
+		if err == nil {
+			for _, p := range params {
+				p.Values = nil
+			}
+			err = this.Parse(args)
+		}
+		if err != nil {
+			return fmt.Errorf("Example \"%s\" of command \"%s\": %s", example.Cmdline, this.Name, err)
+		}
+	}
+	return nil
+}
+
+// SplitCommandLine splits a command line into arguments, considering single
+// and double quotes and backslash escapes
+func SplitCommandLine(s string) ([]string, error) {
+	args := []string{}
+	current := []rune{}
+	inArg := false
+	var quote rune
+	escaped := false
+	for _, r := range s {
+		if escaped {
+			current = append(current, r)
+			escaped = false
+		} else if r == '\\' && quote != '\'' {
+			escaped = true
+			inArg = true
+		} else if quote != 0 {
+			if r == quote {
+				quote = 0
+			} else {
+				current = append(current, r)
+			}
+		} else if r == '"' || r == '\'' {
+			quote = r
+			inArg = true
+		} else if strings.ContainsRune(" \t\r\n", r) {
+			if inArg {
+				args = append(args, string(current))
+				current = []rune{}
+				inArg = false
+			}
+		} else {
+			current = append(current, r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("Unterminated quote in \"%s\"", s)
+	} else if escaped {
+		return nil, fmt.Errorf("Unterminated escape in \"%s\"", s)
+	}
+	if inArg {
+		args = append(args, string(current))
+	}
+	return args, nil
+}
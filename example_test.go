@@ -0,0 +1,59 @@
+package clif
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+var testsSplitCommandLine = []struct {
+	from   string
+	expect []string
+	err    bool
+}{
+	{from: "", expect: []string{}},
+	{from: "foo  bar\tbaz", expect: []string{"foo", "bar", "baz"}},
+	{from: `--foo "bar baz" 'b"o' x\ y`, expect: []string{"--foo", "bar baz", `b"o`, "x y"}},
+	{from: `--foo="" 'it\'`, expect: []string{"--foo=", `it\`}},
+	{from: `foo "bar`, err: true},
+	{from: `foo\`, err: true},
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	Convey("Split command line into arguments", t, func() {
+		for idx, test := range testsSplitCommandLine {
+			Convey(fmt.Sprintf("%d) %q", idx, test.from), func() {
+				args, err := SplitCommandLine(test.from)
+				if test.err {
+					So(err, ShouldNotBeNil)
+				} else {
+					So(err, ShouldBeNil)
+					So(args, ShouldResemble, test.expect)
+				}
+			})
+		}
+	})
+}
+
+func TestParseExamples(t *testing.T) {
+	Convey("Parse examples of command", t, func() {
+		c := NewCommand("foo", "It does foo", func() {}).
+			NewArgument("bar", "The bar", "", true, false).
+			NewOption("baz", "b", "The baz", "", false, true).
+			AddExample(`bar1 --baz "x y"`, "Do foo with bar1").
+			AddExample("bar2 -b x -b y", "")
+		So(c.ParseExamples(), ShouldBeNil)
+
+		Convey("Values are restored", func() {
+			So(c.Argument("bar").String(), ShouldEqual, "")
+			So(c.Option("baz").Strings(), ShouldBeNil)
+		})
+
+		Convey("Invalid examples fail", func() {
+			c.AddExample("--baz x", "Missing bar")
+			err := c.ParseExamples()
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, `Example "--baz x" of command "foo": Argument "bar" is required but missing`)
+		})
+	})
+}
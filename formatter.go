@@ -100,6 +100,15 @@ var DefaultFormatterEscape = func(msg string) string {
 	})
 }
 
+// Styles returns the styles of the formatter. Formatters without styles strip
+// the tokens of `DefaultStyles`, which are returned then.
+func (this *DefaultFormatter) Styles() map[string]string {
+	if this.styles == nil {
+		return DefaultStyles
+	}
+	return this.styles
+}
+
 func (this *DefaultFormatter) Escape(msg string) string {
 	msg = DefaultFormatterPre(msg)
 	msg = DefaultFormatterEscape(msg)
@@ -132,6 +141,17 @@ func (this *DefaultFormatter) Format(msg string) string {
 	return msg
 }
 
+// formatterStyles returns the styles of a formatter, if it provides them, or
+// `DefaultStyles` otherwise
+func formatterStyles(f Formatter) map[string]string {
+	if provider, ok := f.(interface {
+		Styles() map[string]string
+	}); ok {
+		return provider.Styles()
+	}
+	return DefaultStyles
+}
+
 func init() {
 
 	// for each token `foo` add a token `/foo`, which contains reset, so we can do "<error>bla</error>"
@@ -0,0 +1,267 @@
+package clif
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Layout renders blocks of text, which may contain style tokens (eg `<info>`),
+// within a fixed width. Style tokens do not count towards widths, wide runes
+// count double. Rendered strings still contain the style tokens, so they
+// should be written with `Output.Printf("%s", ..)`.
+type Layout struct {
+
+	// Width is the total width of the rendered blocks
+	Width int
+
+	// Style provides the border characters of boxes
+	Style *TableStyle
+
+	// Styles are the formatter styles, of which tokens do not count towards
+	// widths. Defaults to `DefaultStyles`.
+	Styles map[string]string
+}
+
+var (
+	// rxLayoutEmptyStyle matches styles which are opened and immediately reset,
+	// as left by wrapping at the end of lines
+	rxLayoutEmptyStyle = regexp.MustCompile(`(^|[^\\])(?:<[a-zA-Z][^<>/]*>)+<reset>`)
+
+	// LayoutColumnGap is the whitespace between side-by-side columns
+	LayoutColumnGap = "  "
+
+	// LayoutDefinitionGap is the minimal whitespace between term and definition
+	// in definition lists
+	LayoutDefinitionGap = "  "
+
+	// LayoutBoxTitle renders the title of a box, which is shown in the top border
+	LayoutBoxTitle = func(title string) string {
+		return " <headline>" + title + "<reset> "
+	}
+)
+
+// NewLayout constructs a new layout of given width, using the default table
+// style for boxes. If width is not positive, the terminal width is used.
+func NewLayout(width int) *Layout {
+	if width <= 0 {
+		width = termWidthCurrent()
+	}
+	return &Layout{
+		Width:  width,
+		Style:  NewDefaultTableStyle(),
+		Styles: DefaultStyles,
+	}
+}
+
+// Indent wraps text to the width minus indent and prefixes all lines with
+// indent spaces
+func (this *Layout) Indent(s string, indent int) string {
+	prefix := strings.Repeat(" ", indent)
+	lines := this.wrap(s, this.Width-indent)
+	for idx, line := range lines {
+		if line != "" {
+			lines[idx] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Hanging renders text with a hanging indent: the head (eg an option name) is
+// followed by the text, which starts at column indent in all lines. If the head
+// does not fit before indent, the text starts in the next line.
+func (this *Layout) Hanging(head, s string, indent int) string {
+	prefix := strings.Repeat(" ", indent)
+	lines := this.wrap(s, this.Width-indent)
+	for idx, line := range lines {
+		if line != "" {
+			lines[idx] = prefix + line
+		}
+	}
+	if headLength := this.length(head); headLength < indent {
+		lines[0] = head + strings.Repeat(" ", indent-headLength) + strings.TrimLeft(lines[0], " ")
+	} else {
+		lines = append([]string{head}, lines...)
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), " ")
+}
+
+// Justify wraps text to the width and distributes whitespace between words,
+// so that all lines, except the last line of each paragraph, fill the width
+func (this *Layout) Justify(s string) string {
+	lines := this.wrap(s, this.Width)
+	for idx, line := range lines {
+		if idx == len(lines)-1 || lines[idx+1] == "" {
+			continue
+		}
+		lines[idx] = this.justifyLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Center wraps text to the width and centers each line
+func (this *Layout) Center(s string) string {
+	lines := this.wrap(s, this.Width)
+	for idx, line := range lines {
+		if pad := (this.Width - this.length(line)) / 2; pad > 0 && line != "" {
+			lines[idx] = strings.Repeat(" ", pad) + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Columns renders texts side-by-side in columns of equal width, separated by
+// `LayoutColumnGap`
+func (this *Layout) Columns(columns ...string) string {
+	if len(columns) == 0 {
+		return ""
+	}
+	gap := this.length(LayoutColumnGap)
+	width := (this.Width - gap*(len(columns)-1)) / len(columns)
+	if width < 1 {
+		width = 1
+	}
+	wrapped := make([][]string, len(columns))
+	height := 0
+	for idx, column := range columns {
+		wrapped[idx] = this.wrap(column, width)
+		if len(wrapped[idx]) > height {
+			height = len(wrapped[idx])
+		}
+	}
+	lines := make([]string, height)
+	for row := 0; row < height; row++ {
+		cells := make([]string, len(columns))
+		for idx, column := range wrapped {
+			if row < len(column) {
+				cells[idx] = this.pad(column[row], width)
+			} else {
+				cells[idx] = strings.Repeat(" ", width)
+			}
+		}
+		lines[row] = strings.TrimRight(strings.Join(cells, LayoutColumnGap), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Definitions renders a two-column definition list, like the options in the
+// help output. Definitions are aligned after the longest term, which may use
+// at most half of the width. Longer terms are written in their own line.
+func (this *Layout) Definitions(entries [][2]string) string {
+	indent := 0
+	for _, entry := range entries {
+		if l := this.length(entry[0]); l > indent && l <= this.Width/2 {
+			indent = l
+		}
+	}
+	indent += this.length(LayoutDefinitionGap)
+	lines := make([]string, len(entries))
+	for idx, entry := range entries {
+		lines[idx] = this.Hanging(entry[0], entry[1], indent)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Box wraps text into a panel, which is framed by the border characters of the
+// style. The title, if any, is rendered into the top border.
+func (this *Layout) Box(title, s string) string {
+	style := this.Style
+	if style == nil {
+		style = NewDefaultTableStyle()
+	}
+	left := style.Left + style.Prefix
+	right := style.Suffix + style.Right
+	inner := this.Width - StringLength(left) - StringLength(right)
+	if inner < 1 {
+		inner = 1
+	}
+	border := func(leftCorner, horizontal, rightCorner, title string) string {
+		if horizontal == "" {
+			horizontal = " "
+		}
+		fill := this.Width - StringLength(leftCorner) - StringLength(rightCorner) - this.length(title)
+		if fill < 0 {
+			fill = 0
+		}
+		lead := 0
+		if title != "" && fill > 0 {
+			lead = 1
+		}
+		return leftCorner + strings.Repeat(horizontal, lead) + title +
+			strings.Repeat(horizontal, fill-lead) + rightCorner
+	}
+
+	lines := []string{}
+	if title != "" {
+		lines = append(lines, border(style.LeftTop, style.Top, style.RightTop, LayoutBoxTitle(title)))
+	} else if style.Top != "" {
+		lines = append(lines, border(style.LeftTop, style.Top, style.RightTop, ""))
+	}
+	for _, line := range this.wrap(s, inner) {
+		lines = append(lines, left+this.pad(line, inner)+right)
+	}
+	if style.Bottom != "" {
+		lines = append(lines, border(style.LeftBottom, style.Bottom, style.RightBottom, ""))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// length returns the display width of a string, ignoring style tokens
+func (this *Layout) length(s string) int {
+	styles := this.Styles
+	if styles == nil {
+		styles = DefaultStyles
+	}
+	s = DefaultFormatterPre(s)
+	s = DefaultFormatterTokenRegex.ReplaceAllStringFunc(s, func(token string) string {
+		if _, ok := styles[token[1:len(token)-1]]; ok {
+			return ""
+		}
+		return token
+	})
+	return StringLength(DefaultFormatterPost(s))
+}
+
+// pad appends whitespace to the string, so that it fills the width
+func (this *Layout) pad(s string, width int) string {
+	if pad := width - this.length(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+// wrap wraps text containing style tokens, keeping empty lines which separate
+// paragraphs. Text is not wrapped if the width is not positive.
+func (this *Layout) wrap(s string, width int) []string {
+	if width > 0 {
+		wrapper := NewWrapper(uint(width))
+		wrapper.KeepEmptyLines = true
+		wrapper.Styles = this.Styles
+		s = rxLayoutEmptyStyle.ReplaceAllString(wrapper.WrapTokens(s), "$1")
+	}
+	return strings.Split(s, "\n")
+}
+
+// justifyLine distributes whitespace between the words of a line, so that it
+// fills the width. Leftmost gaps are wider first.
+func (this *Layout) justifyLine(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	lead := line[:len(line)-len(trimmed)]
+	words := strings.Fields(trimmed)
+	if len(words) < 2 {
+		return line
+	}
+	missing := this.Width - this.length(line)
+	if missing <= 0 {
+		return line
+	}
+	gaps := len(words) - 1
+	out := lead + words[0]
+	for idx, word := range words[1:] {
+		spaces := 1 + missing/gaps
+		if idx < missing%gaps {
+			spaces++
+		}
+		out += strings.Repeat(" ", spaces) + word
+	}
+	return out
+}
@@ -0,0 +1,132 @@
+package clif
+
+import (
+	"bytes"
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+)
+
+var testLayoutText = "The <info>quick<reset> brown fox jumps over the lazy dog"
+
+func TestLayoutIndent(t *testing.T) {
+	Convey("Indent text", t, func() {
+		l := NewLayout(20)
+		So(l.Indent(testLayoutText, 4), ShouldEqual, strings.Join([]string{
+			"    The <info>quick<reset> brown",
+			"    fox jumps over",
+			"    the lazy dog",
+		}, "\n"))
+	})
+	Convey("Hanging indent", t, func() {
+		l := NewLayout(20)
+		So(l.Hanging("--foo", testLayoutText, 8), ShouldEqual, strings.Join([]string{
+			"--foo   The <info>quick<reset>",
+			"        brown fox",
+			"        jumps over",
+			"        the lazy dog",
+		}, "\n"))
+		Convey("Head longer than indent", func() {
+			So(l.Hanging("<info>--very-long<reset>", "short text", 8), ShouldEqual, strings.Join([]string{
+				"<info>--very-long<reset>",
+				"        short text",
+			}, "\n"))
+		})
+	})
+}
+
+func TestLayoutJustify(t *testing.T) {
+	Convey("Justify text", t, func() {
+		l := NewLayout(20)
+		So(l.Justify(testLayoutText+"\n\nSecond paragraph is here"), ShouldEqual, strings.Join([]string{
+			"The  <info>quick<reset> brown fox",
+			"jumps  over the lazy",
+			"dog",
+			"",
+			"Second  paragraph is",
+			"here",
+		}, "\n"))
+	})
+	Convey("Justify wide runes", t, func() {
+		l := NewLayout(12)
+		So(l.Justify("日本 語 foo bar"), ShouldEqual, "日本  語 foo\nbar")
+	})
+}
+
+func TestLayoutCenter(t *testing.T) {
+	Convey("Center text", t, func() {
+		l := NewLayout(20)
+		So(l.Center(testLayoutText), ShouldEqual, strings.Join([]string{
+			"The <info>quick<reset> brown fox",
+			"jumps over the lazy",
+			"        dog",
+		}, "\n"))
+		So(l.Center("<headline>Title<reset>"), ShouldEqual, "       <headline>Title<reset>")
+	})
+}
+
+func TestLayoutColumns(t *testing.T) {
+	Convey("Side-by-side columns", t, func() {
+		l := NewLayout(20)
+		So(l.Columns("left column text here", "right <info>column<reset> is longer than the left"), ShouldEqual, strings.Join([]string{
+			"left       right",
+			"column     <info>column<reset> is",
+			"text here  longer",
+			"           than the",
+			"           left",
+		}, "\n"))
+	})
+	Convey("Definition list", t, func() {
+		l := NewLayout(20)
+		So(l.Definitions([][2]string{
+			{"-a", "first option"},
+			{"<info>--bbb<reset>", "second option with longer text"},
+			{"--this-is-too-long", "own line"},
+		}), ShouldEqual, strings.Join([]string{
+			"-a     first option",
+			"<info>--bbb<reset>  second option",
+			"       with longer",
+			"       text",
+			"--this-is-too-long",
+			"       own line",
+		}, "\n"))
+	})
+}
+
+func TestLayoutBox(t *testing.T) {
+	Convey("Box with title", t, func() {
+		l := NewLayout(20)
+		l.Style = CopyTableStyle(ClosedTableStyle)
+		So(l.Box("Title", testLayoutText), ShouldEqual, strings.Join([]string{
+			"┌─ <headline>Title<reset> ──────────┐",
+			"│ The <info>quick<reset> brown  │",
+			"│ fox jumps over   │",
+			"│ the lazy dog     │",
+			"└──────────────────┘",
+		}, "\n"))
+	})
+	Convey("Box without title", t, func() {
+		l := NewLayout(10)
+		l.Style = CopyTableStyle(ClosedTableStyle)
+		So(l.Box("", "日本語"), ShouldEqual, strings.Join([]string{
+			"┌────────┐",
+			"│ 日本語 │",
+			"└────────┘",
+		}, "\n"))
+	})
+	Convey("Box rendered by output", t, func() {
+		b := bytes.NewBuffer(nil)
+		o := NewMonochromeOutput(b)
+		o.Printf("%s\n", o.Layout(12).Box("Hi", "<info>foo<reset>"))
+		So(b.String(), ShouldEqual, "┌─ Hi ─────┐\n│ foo      │\n└──────────┘\n")
+	})
+}
+
+func TestLayoutStyles(t *testing.T) {
+	Convey("Layout of output ignores tokens of its styles", t, func() {
+		b := bytes.NewBuffer(nil)
+		o := NewOutput(b, NewDefaultFormatter(map[string]string{"accent": "\033[35m", "reset": "\033[0m"}))
+		o.Printf("%s\n", o.Layout(12).Box("", "<accent>foo<reset>"))
+		So(b.String(), ShouldEqual, "┌──────────┐\n│ \033[35mfoo\033[0m      │\n└──────────┘\n")
+	})
+}
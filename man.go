@@ -0,0 +1,243 @@
+package clif
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	MAN_SECTION = "1"
+)
+
+var (
+	// ManPageDate returns the date printed in the man page header
+	ManPageDate = func() time.Time {
+		return time.Now()
+	}
+
+	// ManPageCli implements the roff rendering of the application man page.
+	// Can be overwritten at users discretion.
+	ManPageCli = func(c *Cli) string {
+		name := ManPageName(c.Name)
+		lines := []string{manHeader(name, c)}
+
+		lines = append(lines, ".SH NAME")
+		if c.Description != "" {
+			lines = append(lines, manEscape(name)+` \- `+manText(c.Description))
+		} else {
+			lines = append(lines, manEscape(name))
+		}
+
+		lines = append(lines, ".SH SYNOPSIS", ".B "+manEscape(filepath.Base(os.Args[0])), manEscape("command [arg ..] [--opt val ..]"))
+
+		if c.Description != "" {
+			lines = append(lines, ".SH DESCRIPTION", manText(c.Description))
+		}
+
+		commands := manCommands(c)
+		if len(commands) > 0 {
+			lines = append(lines, ".SH COMMANDS")
+			for _, cmd := range commands {
+				lines = append(lines, ".TP", ".B "+manEscape(cmd.Name), manText(cmd.Usage))
+			}
+			seeAlso := []string{}
+			for _, cmd := range commands {
+				seeAlso = append(seeAlso, fmt.Sprintf(`.BR %s (%s)`, manEscape(ManPageName(c.Name, cmd.Name)), MAN_SECTION))
+			}
+			lines = append(lines, ".SH SEE ALSO", strings.Join(seeAlso, ",\n"))
+		}
+
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	// ManPageCommand implements the roff rendering of the man page of a single
+	// command. Can be overwritten at users discretion.
+	ManPageCommand = func(cmd *Command) string {
+		appName := ""
+		if cmd.Cli != nil {
+			appName = cmd.Cli.Name
+		}
+		name := ManPageName(appName, cmd.Name)
+		lines := []string{manHeader(name, cmd.Cli)}
+
+		lines = append(lines, ".SH NAME")
+		if cmd.Usage != "" {
+			lines = append(lines, manEscape(name)+` \- `+manText(cmd.Usage))
+		} else {
+			lines = append(lines, manEscape(name))
+		}
+
+		prog := filepath.Base(os.Args[0])
+		lines = append(lines, ".SH SYNOPSIS", ".B "+manEscape(prog), manEscape(CommandUsage(cmd)))
+
+		if cmd.Description != "" {
+			lines = append(lines, ".SH DESCRIPTION", manText(cmd.Description))
+		}
+
+		if len(cmd.Arguments) > 0 {
+			lines = append(lines, ".SH ARGUMENTS")
+			for _, a := range cmd.Arguments {
+				lines = append(lines, ".TP", ".B "+manEscape(a.Name), manParameter(&a.parameter))
+			}
+		}
+
+		if len(cmd.Options) > 0 {
+			lines = append(lines, ".SH OPTIONS")
+			for _, o := range cmd.Options {
+				head := ".BR " + manEscape("--"+o.Name)
+				if o.Alias != "" {
+					head += ` ", " ` + manEscape("-"+o.Alias)
+				}
+				if !o.Flag {
+					head += ` " " \fIval\fR`
+				}
+				lines = append(lines, ".TP", head, manParameter(&o.parameter))
+			}
+		}
+
+		if len(cmd.Examples) > 0 {
+			lines = append(lines, ".SH EXAMPLES")
+			for _, e := range NewCommandDescription(cmd).Examples {
+				lines = append(lines, ".TP", `\fB`+manEscape(e.Cmdline)+`\fR`, manText(e.Explanation))
+			}
+		}
+
+		if appName != "" {
+			lines = append(lines, ".SH SEE ALSO", fmt.Sprintf(`.BR %s (%s)`, manEscape(ManPageName(appName)), MAN_SECTION))
+		}
+
+		return strings.Join(lines, "\n") + "\n"
+	}
+)
+
+// ManPageName returns the name of a man page, eg "my-app" for the application
+// "My App" or "my-app-foo-bar" for its command "foo:bar"
+func ManPageName(names ...string) string {
+	parts := []string{}
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			parts = append(parts, name)
+		}
+	}
+	name := strings.ToLower(strings.Join(parts, "-"))
+	return strings.NewReplacer(" ", "-", ":", "-", "/", "-").Replace(name)
+}
+
+// NewManCommand returns the hidden "man" command, which prints the roff man
+// page of the application or of a given command, or writes the man pages of
+// the application and all commands into a directory. It is added in `New()`.
+func NewManCommand() *Command {
+	return NewCommand("man", "Generate man pages", func(c *Cli, cmd *Command, out Output) error {
+		if dir := cmd.Option("dir").String(); dir != "" {
+			return c.GenerateManPages(dir)
+		} else if name := cmd.Argument("command").String(); name != "" {
+			if sub, ok := c.Commands[name]; ok {
+				_, err := io.WriteString(out.Writer(), ManPageCommand(sub))
+				return err
+			}
+			return fmt.Errorf("Unknown command \"%s\"", name)
+		}
+		_, err := io.WriteString(out.Writer(), ManPageCli(c))
+		return err
+	}).
+		SetHidden(true).
+		NewArgument("command", "Command to print man page for", "", false, false).
+		NewOption("dir", "d", "Write man pages of application and all commands into directory", "", false, false)
+}
+
+// GenerateManPages writes the man page of the application and of all not
+// hidden commands into the given directory, eg "my-app.1", "my-app-foo.1" ..
+func (this *Cli) GenerateManPages(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	write := func(name, content string) error {
+		path := filepath.Join(dir, name+"."+MAN_SECTION)
+		return ioutil.WriteFile(path, []byte(content), 0644)
+	}
+	if err := write(ManPageName(this.Name), ManPageCli(this)); err != nil {
+		return err
+	}
+	for _, cmd := range manCommands(this) {
+		if err := write(ManPageName(this.Name, cmd.Name), ManPageCommand(cmd)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func manCommands(c *Cli) []*Command {
+	commands := []*Command{}
+	for _, cmd := range c.Commands {
+		if !cmd.Hidden {
+			commands = append(commands, cmd)
+		}
+	}
+	sort.Sort(CommandsSort(commands))
+	return commands
+}
+
+func manHeader(name string, c *Cli) string {
+	source := ""
+	manual := ""
+	if c != nil {
+		source = strings.TrimSpace(c.Name + " " + c.Version)
+		manual = c.Name + " Manual"
+	}
+	return fmt.Sprintf(`.TH "%s" "%s" "%s" "%s" "%s"`, manEscape(strings.ToUpper(name)), MAN_SECTION,
+		ManPageDate().Format("January 2006"), manEscape(source), manEscape(manual))
+}
+
+func manParameter(p *parameter) string {
+	text := manText(p.Usage)
+	if p.Description != "" {
+		text += "\n.br\n" + manText(p.Description)
+	}
+	info := []string{}
+	if p.Required {
+		info = append(info, "required")
+	}
+	if p.Multiple {
+		info = append(info, "multiple")
+	}
+	if p.Env != "" {
+		info = append(info, `env: \fB`+manEscape(p.Env)+`\fR`)
+	}
+	if p.Default != "" {
+		info = append(info, `default: "`+manEscape(p.Default)+`"`)
+	}
+	if len(info) > 0 {
+		text += "\n.br\n(" + strings.Join(info, ", ") + ")"
+	}
+	return text
+}
+
+// manText strips style tokens and escapes text for roff, keeping paragraphs
+func manText(s string) string {
+	s = NewDefaultFormatter(nil).Format(s)
+	paragraphs := strings.Split(strings.TrimSpace(s), "\n\n")
+	for i, p := range paragraphs {
+		lines := strings.Split(p, "\n")
+		for j, l := range lines {
+			lines[j] = manEscape(strings.TrimSpace(l))
+		}
+		paragraphs[i] = strings.Join(lines, "\n")
+	}
+	return strings.Join(paragraphs, "\n.PP\n")
+}
+
+// manEscape escapes roff control characters
+func manEscape(s string) string {
+	s = strings.Replace(s, `\`, `\e`, -1)
+	s = strings.Replace(s, "-", `\-`, -1)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
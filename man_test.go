@@ -0,0 +1,139 @@
+package clif
+
+import (
+	"bytes"
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func _testManCli() *Cli {
+	c := New("My App", "1.0.1", "My <info>CLI<reset>").
+		New("foo", "It does foo", func() {}).
+		New("zzz:uno", "A sub of zzz", func() {})
+	c.Commands["foo"].
+		SetDescription("It does really, really foo.\n\nAnd more").
+		NewArgument("bar", "The bar", "", true, false).
+		NewOption("boing", "b", "The boing!", "default", false, true)
+	c.Commands["foo"].Option("boing").SetEnv("THE_BOING")
+	c.Commands["foo"].AddExample(`bar1 --boing "x y"`, "Do foo with bar1")
+	return c
+}
+
+func TestManPage(t *testing.T) {
+	orig := ManPageDate
+	ManPageDate = func() time.Time {
+		return time.Date(2016, 1, 2, 0, 0, 0, 0, time.UTC)
+	}
+	defer func() {
+		ManPageDate = orig
+	}()
+
+	Convey("Man page of cli", t, func() {
+		c := _testManCli()
+		expect := `.TH "MY\-APP" "1" "January 2016" "My App 1.0.1" "My App Manual"
+.SH NAME
+my\-app \- My CLI
+.SH SYNOPSIS
+.B clif.test
+command [arg ..] [\-\-opt val ..]
+.SH DESCRIPTION
+My CLI
+.SH COMMANDS
+.TP
+.B foo
+It does foo
+.TP
+.B help
+Show this help
+.TP
+.B list
+List all available commands
+.TP
+.B zzz:uno
+A sub of zzz
+.SH SEE ALSO
+.BR my\-app\-foo (1),
+.BR my\-app\-help (1),
+.BR my\-app\-list (1),
+.BR my\-app\-zzz\-uno (1)
+`
+		So(ManPageCli(c), ShouldEqual, expect)
+	})
+
+	Convey("Man page of command", t, func() {
+		c := _testManCli()
+		expect := `.TH "MY\-APP\-FOO" "1" "January 2016" "My App 1.0.1" "My App Manual"
+.SH NAME
+my\-app\-foo \- It does foo
+.SH SYNOPSIS
+.B clif.test
+foo bar [\-\-help|\-h] [\-\-boing|\-b val ...]
+.SH DESCRIPTION
+It does really, really foo.
+.PP
+And more
+.SH ARGUMENTS
+.TP
+.B bar
+The bar
+.br
+(required)
+.SH OPTIONS
+.TP
+.BR \-\-help ", " \-h
+Display this help message
+.br
+Display this help message
+.TP
+.BR \-\-boing ", " \-b " " \fIval\fR
+The boing!
+.br
+(multiple, env: \fBTHE_BOING\fR, default: "default")
+.SH EXAMPLES
+.TP
+\fBclif.test foo bar1 \-\-boing "x y"\fR
+Do foo with bar1
+.SH SEE ALSO
+.BR my\-app (1)
+`
+		So(ManPageCommand(c.Commands["foo"]), ShouldEqual, expect)
+	})
+
+	Convey("Man command", t, func() {
+		c := _testManCli()
+		buf := bytes.NewBuffer(nil)
+		c.SetOutput(NewMonochromeOutput(buf))
+
+		Convey("Is hidden", func() {
+			So(DescribeCli(c), ShouldNotContainSubstring, "<info>man")
+		})
+
+		Convey("Prints man page of cli", func() {
+			c.RunWith([]string{"man"})
+			So(buf.String(), ShouldEqual, ManPageCli(c))
+		})
+
+		Convey("Prints man page of command", func() {
+			c.RunWith([]string{"man", "foo"})
+			So(buf.String(), ShouldEqual, ManPageCommand(c.Commands["foo"]))
+		})
+
+		Convey("Writes all man pages into directory", func() {
+			dir, _ := ioutil.TempDir("", "clif-man")
+			defer os.RemoveAll(dir)
+			c.RunWith([]string{"man", "--dir", dir})
+			files, _ := filepath.Glob(filepath.Join(dir, "*"))
+			names := []string{}
+			for _, file := range files {
+				names = append(names, filepath.Base(file))
+			}
+			So(names, ShouldResemble, []string{
+				"my-app-foo.1", "my-app-help.1", "my-app-list.1", "my-app-zzz-uno.1", "my-app.1",
+			})
+		})
+	})
+}
@@ -0,0 +1,173 @@
+package clif
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	rxMarkdownHeading  = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*$`)
+	rxMarkdownList     = regexp.MustCompile(`^(\s*)([-*+]|[0-9]+[.)])\s+(.*)$`)
+	rxMarkdownFence    = regexp.MustCompile("^\\s*(```|~~~)")
+	rxMarkdownCode     = regexp.MustCompile("`([^`]+)`")
+	rxMarkdownLink     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	rxMarkdownAutoLink = regexp.MustCompile(`<((?:https?|ftp|mailto):[^>\s]+)>`)
+	rxMarkdownStrong   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	rxMarkdownEm       = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_\s][^_]*)_\b`)
+	rxMarkdownCodeHold = regexp.MustCompile(`\x00(\d+)\x00`)
+
+	// MarkdownBullet is the character used to render unordered list items
+	MarkdownBullet = "•"
+
+	// MarkdownCodeIndent is the prefix of each line in a fenced code block
+	MarkdownCodeIndent = "    "
+
+	// MarkdownStyles maps Markdown elements to the style tokens they are
+	// rendered with. Custom tokens (eg `strong`) must be known by the formatter
+	// of the output.
+	MarkdownStyles = map[string]string{
+		"heading":    "headline",
+		"subheading": "subline",
+		"strong":     "important",
+		"em":         "subline",
+		"code":       "query",
+		"link":       "info",
+	}
+)
+
+// RenderMarkdown renders a subset of Markdown into a string containing style
+// tokens, which can be printed with an `Output`. Supported are headings,
+// emphasis, inline code and fenced code blocks, bullet and numbered lists and
+// links, which are rendered with the tokens of `MarkdownStyles`. Paragraphs and list items are wrapped to the
+// given width, unless the width is 0. Optional styles are the formatter styles,
+// of which tokens do not count towards the width (see `Wrapper.Styles`).
+func RenderMarkdown(src string, width int, styles ...map[string]string) string {
+	lines := strings.Split(strings.Replace(src, "\r\n", "\n", -1), "\n")
+	out := []string{}
+	block := []string{}
+	blockPrefix := ""
+	blockIndent := ""
+	inCode := false
+
+	addEmpty := func() {
+		if l := len(out); l > 0 && out[l-1] != "" {
+			out = append(out, "")
+		}
+	}
+	flush := func() {
+		if len(block) == 0 {
+			return
+		}
+		rendered := renderMarkdownInline(strings.Join(block, " "), "")
+		if width > 0 {
+			limit := width - StringLength(blockPrefix)
+			if limit < 1 {
+				limit = 1
+			}
+			wrapper := NewWrapper(uint(limit))
+			if len(styles) > 0 {
+				wrapper.Styles = styles[0]
+			}
+			rendered = wrapper.WrapTokens(rendered)
+		}
+		for idx, line := range strings.Split(rendered, "\n") {
+			if idx == 0 {
+				out = append(out, blockPrefix+line)
+			} else {
+				out = append(out, blockIndent+line)
+			}
+		}
+		block = []string{}
+		blockPrefix = ""
+		blockIndent = ""
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		// fenced code blocks are not interpreted or wrapped
+		if rxMarkdownFence.MatchString(line) {
+			flush()
+			addEmpty()
+			inCode = !inCode
+			continue
+		} else if inCode {
+			out = append(out, MarkdownCodeIndent+markdownToken("code")+escapeMarkdown(line)+"<reset>")
+			continue
+		}
+
+		if trimmed == "" {
+			flush()
+			addEmpty()
+		} else if match := rxMarkdownHeading.FindStringSubmatch(trimmed); match != nil {
+			flush()
+			addEmpty()
+			style := "subheading"
+			if len(match[1]) == 1 {
+				style = "heading"
+			}
+			out = append(out, renderMarkdownInline(match[2], style), "")
+		} else if match := rxMarkdownList.FindStringSubmatch(line); match != nil {
+			flush()
+			indent := strings.Repeat("  ", len(strings.Replace(match[1], "\t", "  ", -1))/2+1)
+			marker := match[2]
+			if marker == "-" || marker == "*" || marker == "+" {
+				marker = MarkdownBullet
+			}
+			blockPrefix = fmt.Sprintf("%s%s ", indent, marker)
+			blockIndent = strings.Repeat(" ", StringLength(blockPrefix))
+			block = append(block, strings.TrimSpace(match[3]))
+		} else {
+			block = append(block, trimmed)
+		}
+	}
+	flush()
+
+	return strings.Trim(strings.Join(out, "\n"), "\n")
+}
+
+// renderMarkdownInline renders inline code, links and emphasis. If a base style
+// is given, then it surrounds the whole string and is re-opened after each
+// inline style ended.
+func renderMarkdownInline(s, base string) string {
+	link, code := markdownToken("link"), markdownToken("code")
+
+	// take out code spans, so that they are not interpreted
+	codes := []string{}
+	s = rxMarkdownCode.ReplaceAllStringFunc(s, func(m string) string {
+		codes = append(codes, escapeMarkdown(m[1:len(m)-1]))
+		return fmt.Sprintf("\x00%d\x00", len(codes)-1)
+	})
+
+	s = rxMarkdownLink.ReplaceAllStringFunc(s, func(m string) string {
+		match := rxMarkdownLink.FindStringSubmatch(m)
+		if match[1] == match[2] {
+			return link + match[2] + "<reset>"
+		}
+		return link + match[1] + "<reset> (" + match[2] + ")"
+	})
+	s = rxMarkdownAutoLink.ReplaceAllString(s, link+"$1<reset>")
+	s = rxMarkdownStrong.ReplaceAllString(s, markdownToken("strong")+"$1$2<reset>")
+	s = rxMarkdownEm.ReplaceAllString(s, markdownToken("em")+"$1$2<reset>")
+	s = rxMarkdownCodeHold.ReplaceAllStringFunc(s, func(m string) string {
+		var idx int
+		fmt.Sscanf(strings.Trim(m, "\x00"), "%d", &idx)
+		return code + codes[idx] + "<reset>"
+	})
+
+	if base != "" {
+		token := markdownToken(base)
+		s = token + strings.Replace(s, "<reset>", "<reset>"+token, -1) + "<reset>"
+	}
+	return s
+}
+
+// markdownToken returns the style token of a Markdown element
+func markdownToken(element string) string {
+	return "<" + MarkdownStyles[element] + ">"
+}
+
+func escapeMarkdown(s string) string {
+	return new(DefaultFormatter).Escape(s)
+}
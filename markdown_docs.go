@@ -0,0 +1,143 @@
+package clif
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+var (
+	// MarkdownDocsIndex is the file name of the index of the Markdown docs
+	MarkdownDocsIndex = "index.md"
+
+	// MarkdownDocsFileName returns the file name of the Markdown docs of a
+	// command, eg "foo-bar.md" for the command "foo:bar"
+	MarkdownDocsFileName = func(name string) string {
+		return strings.NewReplacer(" ", "-", ":", "-", "/", "-").Replace(name) + ".md"
+	}
+
+	// MarkdownDocsIndexTemplate is the `text/template` of the index file. It is
+	// executed with a `*CliDescription`.
+	MarkdownDocsIndexTemplate = `# {{ plain .Name }}{{ if .Version }} ({{ .Version }}){{ end }}
+{{ if .Description }}
+{{ plain .Description }}
+{{ end }}
+## Usage
+
+` + "```" + `
+{{ .Program }} {{ .Usage }}
+` + "```" + `
+
+## Commands
+{{ range .Groups }}{{ if .Prefix }}
+### {{ .Prefix }}
+{{ end }}
+{{ range .Commands }}- [{{ .Name }}]({{ file .Name }}){{ if .Usage }}: {{ plain .Usage }}{{ end }}
+{{ end }}{{ end }}`
+
+	// MarkdownDocsCommandTemplate is the `text/template` of the file of a
+	// command. It is executed with a `*CommandDescription`.
+	MarkdownDocsCommandTemplate = `# {{ .Name }}
+{{ if .Usage }}
+{{ plain .Usage }}
+{{ end }}
+## Usage
+
+` + "```" + `
+{{ if .Cli }}{{ .Cli.Program }} {{ end }}{{ .Synopsis }}
+` + "```" + `
+{{ if .Description }}
+## Description
+
+{{ plain .Description }}
+{{ end }}{{ if .Arguments }}
+## Arguments
+
+{{ range .Arguments }}- ` + "`{{ .Label }}`" + `{{ if .Usage }}: {{ plain .Usage }}{{ end }}{{ with info . }} ({{ . }}){{ end }}
+{{ end }}{{ end }}{{ if .Options }}
+## Options
+
+{{ range .Options }}- ` + "`{{ .Label }}`" + `{{ if .Usage }}: {{ plain .Usage }}{{ end }}{{ with info . }} ({{ . }}){{ end }}
+{{ end }}{{ end }}{{ if .Examples }}
+## Examples
+{{ range .Examples }}{{ with .Explanation }}
+{{ plain . }}
+{{ end }}
+` + "```" + `
+{{ .Cmdline }}
+` + "```" + `
+{{ end }}{{ end }}{{ if .Cli }}
+## See also
+
+- [{{ plain .Cli.Name }}]({{ indexFile }})
+{{ end }}`
+
+	// MarkdownDocsFuncs are the functions available in the Markdown docs
+	// templates
+	MarkdownDocsFuncs = template.FuncMap{
+		"plain": func(s string) string {
+			return NewDefaultFormatter(nil).Format(s)
+		},
+		"file":      func(name string) string { return MarkdownDocsFileName(name) },
+		"indexFile": func() string { return MarkdownDocsIndex },
+		"info":      markdownDocsInfo,
+	}
+)
+
+// GenerateMarkdownDocs writes the Markdown reference documentation of the
+// application into the given directory: an index file (see
+// `MarkdownDocsIndex`) and one file per not hidden command. The content is
+// rendered with `MarkdownDocsIndexTemplate` and `MarkdownDocsCommandTemplate`.
+func (this *Cli) GenerateMarkdownDocs(dir string) error {
+	indexTpl, err := template.New("index").Funcs(MarkdownDocsFuncs).Parse(MarkdownDocsIndexTemplate)
+	if err != nil {
+		return fmt.Errorf("Failed to parse index template: %s", err)
+	}
+	commandTpl, err := template.New("command").Funcs(MarkdownDocsFuncs).Parse(MarkdownDocsCommandTemplate)
+	if err != nil {
+		return fmt.Errorf("Failed to parse command template: %s", err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	write := func(name string, tpl *template.Template, data interface{}) error {
+		buf := bytes.NewBuffer(nil)
+		if err := tpl.Execute(buf, data); err != nil {
+			return fmt.Errorf("Failed to render %s: %s", name, err)
+		}
+		return ioutil.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0644)
+	}
+
+	desc := NewCliDescription(this)
+	if err := write(MarkdownDocsIndex, indexTpl, desc); err != nil {
+		return err
+	}
+	for _, cmd := range desc.Commands {
+		if err := write(MarkdownDocsFileName(cmd.Name), commandTpl, cmd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func markdownDocsInfo(p *ParameterDescription) string {
+	info := []string{}
+	if p.Required {
+		info = append(info, "required")
+	}
+	if p.Multiple {
+		info = append(info, "multiple")
+	}
+	if p.Env != "" {
+		info = append(info, "env: `"+p.Env+"`")
+	}
+	if p.Default != "" {
+		info = append(info, "default: `"+p.Default+"`")
+	}
+	return strings.Join(info, ", ")
+}
@@ -0,0 +1,70 @@
+package clif
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerateMarkdownDocs(t *testing.T) {
+	Convey("Generate markdown docs", t, func() {
+		c := New("My App", "1.0.1", "My <info>CLI<reset>").
+			New("foo", "It does foo", func() {}).
+			New("zzz:uno", "A sub of zzz", func() {})
+		c.Commands["foo"].
+			SetDescription("It does **really** foo").
+			NewArgument("bar", "The bar", "", true, false).
+			NewOption("boing", "b", "The boing!", "default", false, true)
+		c.Commands["foo"].Option("boing").SetEnv("THE_BOING")
+		c.Commands["foo"].AddExample("bar1", "Do foo with bar1")
+		c.Add(NewManCommand())
+
+		dir, _ := ioutil.TempDir("", "clif-docs")
+		defer os.RemoveAll(dir)
+		So(c.GenerateMarkdownDocs(dir), ShouldBeNil)
+
+		files, _ := filepath.Glob(filepath.Join(dir, "*"))
+		names := []string{}
+		for _, file := range files {
+			names = append(names, filepath.Base(file))
+		}
+		So(names, ShouldResemble, []string{"foo.md", "help.md", "index.md", "list.md", "zzz-uno.md"})
+
+		Convey("Index lists all commands", func() {
+			raw, _ := ioutil.ReadFile(filepath.Join(dir, "index.md"))
+			So(string(raw), ShouldEqual, "# My App (1.0.1)\n\nMy CLI\n\n## Usage\n\n```\n"+
+				"clif.test command [arg ..] [--opt val ..]\n```\n\n## Commands\n\n"+
+				"- [foo](foo.md): It does foo\n"+
+				"- [help](help.md): Show this help\n"+
+				"- [list](list.md): List all available commands\n\n"+
+				"### zzz\n\n"+
+				"- [zzz:uno](zzz-uno.md): A sub of zzz\n")
+		})
+
+		Convey("Command file describes command", func() {
+			raw, _ := ioutil.ReadFile(filepath.Join(dir, "foo.md"))
+			So(string(raw), ShouldEqual, "# foo\n\nIt does foo\n\n## Usage\n\n```\n"+
+				"clif.test foo bar [--help|-h] [--boing|-b val ...]\n```\n\n"+
+				"## Description\n\nIt does **really** foo\n\n"+
+				"## Arguments\n\n- `bar`: The bar (required)\n\n"+
+				"## Options\n\n"+
+				"- `--help|-h`: Display this help message\n"+
+				"- `--boing|-b val`: The boing! (multiple, env: `THE_BOING`, default: `default`)\n\n"+
+				"## Examples\n\nDo foo with bar1\n\n```\nclif.test foo bar1\n```\n\n"+
+				"## See also\n\n- [My App](index.md)\n")
+		})
+
+		Convey("Templates can be customized", func() {
+			orig := MarkdownDocsCommandTemplate
+			defer func() {
+				MarkdownDocsCommandTemplate = orig
+			}()
+			MarkdownDocsCommandTemplate = "{{ .Name }} of {{ .Cli.Name }}"
+			So(c.GenerateMarkdownDocs(dir), ShouldBeNil)
+			raw, _ := ioutil.ReadFile(filepath.Join(dir, "zzz-uno.md"))
+			So(string(raw), ShouldEqual, "zzz:uno of My App")
+		})
+	})
+}
@@ -0,0 +1,91 @@
+package clif
+
+import (
+	"bytes"
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+var testsRenderMarkdown = []struct {
+	from   string
+	width  int
+	expect string
+}{
+	{
+		from:   "# Foo\n\nSome text",
+		expect: "<headline>Foo<reset>\n\nSome text",
+	},
+	{
+		from:   "## Foo *bar*",
+		expect: "<subline>Foo <subline>bar<reset><subline><reset>",
+	},
+	{
+		from:   "With **strong**, _em_ and `<code>` but not snake_case_name",
+		expect: "With <important>strong<reset>, <subline>em<reset> and <query>\\<code><reset> but not snake_case_name",
+	},
+	{
+		from:   "A [link](http://example.com) and <http://example.org>",
+		expect: "A <info>link<reset> (http://example.com) and <info>http://example.org<reset>",
+	},
+	{
+		from:   "A paragraph\nover lines\n\n- one\n- two\n  - three\n1. first",
+		expect: "A paragraph over lines\n\n  • one\n  • two\n    • three\n  1. first",
+	},
+	{
+		from:   "Text\n```\nfoo <bar>\n  baz\n```\nMore",
+		expect: "Text\n\n    <query>foo \\<bar><reset>\n    <query>  baz<reset>\n\nMore",
+	},
+	{
+		from:   "Foo bar baz **bar baz**",
+		width:  8,
+		expect: "Foo bar\nbaz <important>bar<reset>\n<important>baz<reset>",
+	},
+	{
+		from:   "- foo bar baz boing",
+		width:  12,
+		expect: "  • foo bar\n    baz\n    boing",
+	},
+	{
+		from:   "Foo **bar baz** boing",
+		width:  8,
+		expect: "Foo <important>bar<reset>\n<important>baz<reset>\nboing",
+	},
+	{
+		from:   "**foo bar baz**",
+		width:  8,
+		expect: "<important>foo bar<reset>\n<important>baz<reset>",
+	},
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	Convey("Render markdown into style tokens", t, func() {
+		for idx, test := range testsRenderMarkdown {
+			Convey(fmt.Sprintf("%d) %q", idx, test.from), func() {
+				So(RenderMarkdown(test.from, test.width), ShouldEqual, test.expect)
+			})
+		}
+	})
+	Convey("Render markdown with tokens of custom styles", t, func() {
+		styles := map[string]string{"accent": "A>", "reset": "R>"}
+		So(RenderMarkdown("<accent>aaaa bbbb cccc<reset> dd", 9, styles), ShouldEqual,
+			"<accent>aaaa bbbb<reset>\n<accent>cccc<reset> dd")
+	})
+}
+
+func TestOutputMarkdown(t *testing.T) {
+	Convey("Print markdown with output", t, func() {
+		buf := bytes.NewBuffer(nil)
+		out := NewMonochromeOutput(buf)
+		out.Markdown("# Foo\n\nSome **bar** with `<baz>`")
+		So(buf.String(), ShouldEqual, "Foo\n\nSome bar with <baz>\n")
+	})
+	Convey("Print markdown with custom tokens", t, func() {
+		defer func(styles map[string]string) { MarkdownStyles = styles }(MarkdownStyles)
+		MarkdownStyles = map[string]string{"strong": "strong"}
+		buf := bytes.NewBuffer(nil)
+		out := NewOutput(buf, NewDefaultFormatter(map[string]string{"strong": "B:", "reset": "R:"}))
+		out.Markdown("Some **bar**")
+		So(buf.String(), ShouldEqual, "Some B:barR:\n")
+	})
+}
@@ -13,6 +13,19 @@ type Output interface {
 	// Escape escapes a string, so that no formatter tokens will be interpolated (eg `<foo>` -> `\<foo>`)
 	Escape(s string) string
 
+	// Layout returns a layout of the given width (defaults to the terminal
+	// width), which uses the table style of the output for boxes
+	Layout(width ...int) *Layout
+
+	// Markdown renders a subset of Markdown (see `RenderMarkdown()`), wrapped
+	// to the terminal width, and writes to output
+	Markdown(src string)
+
+	// Pager returns a writer, which pipes output through `$PAGER`, if the
+	// output is a terminal and the written content is longer than the
+	// terminal is high. Output is written on `Close()`.
+	Pager() io.WriteCloser
+
 	// Printf applies format (renders styles) and writes to output
 	Printf(msg string, args ...interface{})
 
@@ -25,18 +38,28 @@ type Output interface {
 	// SetFormatter is builder method and replaces current formatter
 	SetFormatter(f Formatter) Output
 
+	// SetTheme is builder method and applies the styles, table style and
+	// progress bar style of the theme. Monochrome outputs keep their formatter
+	// and colors are removed from the table style, so that they stay plain.
+	SetTheme(theme *Theme) Output
+
 	// Table creates a table object
 	Table(header []string, style ...*TableStyle) *Table
 
+	// TableStream creates a table object, which writes rows to output as they
+	// are added, instead of keeping them in memory
+	TableStream(header []string, style ...*TableStyle) *TableStream
+
 	// Writer returns the `io.Writer` used by this output
 	Writer() io.Writer
 }
 
 // DefaultOutput is the default used output type
 type DefaultOutput struct {
-	fmt    Formatter
-	io     io.Writer
-	pbPool ProgressBarPool
+	fmt        Formatter
+	io         io.Writer
+	pbPool     ProgressBarPool
+	tableStyle *TableStyle
 }
 
 var (
@@ -45,6 +68,11 @@ var (
 			return strings.Join(SplitFormattedString(out.Sprintf("<headline>%s<reset>", content)), "\n")
 		}
 	}
+	DefaultOutputTableFooterRenderer = func(out Output) func(string) string {
+		return func(content string) string {
+			return strings.Join(SplitFormattedString(out.Sprintf("<headline>%s<reset>", content)), "\n")
+		}
+	}
 	DefaultOutputTableContentRenderer = func(out Output) func(string) string {
 		return func(content string) string {
 			from := strings.Replace(content, "\n", "<BR>", -1)
@@ -61,11 +89,14 @@ func NewOutput(io io.Writer, f Formatter) *DefaultOutput {
 	if io == nil {
 		io = os.Stdout
 	}
-	return &DefaultOutput{
+	out := &DefaultOutput{
 		fmt:    f,
 		io:     io,
 		pbPool: NewProgressBarPool(),
 	}
+	out.pbPool.Formatter(f)
+	out.pbPool.Writer(io)
+	return out
 }
 
 // NewMonochromeOutput returns default output (on `os.Stdout`, if io is nil) using
@@ -88,6 +119,23 @@ func NewDebugOutput(io io.Writer) *DefaultOutput {
 
 func (this *DefaultOutput) SetFormatter(f Formatter) Output {
 	this.fmt = f
+	this.pbPool.Formatter(f)
+	return this
+}
+
+func (this *DefaultOutput) SetTheme(theme *Theme) Output {
+	this.tableStyle = theme.TableStyle
+	if f, ok := this.fmt.(*DefaultFormatter); ok && f.styles == nil {
+		if this.tableStyle != nil {
+			this.tableStyle = PlainTableStyle(this.tableStyle)
+		}
+	} else {
+		this.fmt = NewDefaultFormatter(theme.Styles)
+		this.pbPool.Formatter(this.fmt)
+	}
+	if theme.ProgressBarStyle != nil {
+		this.pbPool.Style(theme.ProgressBarStyle)
+	}
 	return this
 }
 
@@ -95,6 +143,26 @@ func (this *DefaultOutput) Escape(msg string) string {
 	return this.fmt.Escape(msg)
 }
 
+func (this *DefaultOutput) Layout(width ...int) *Layout {
+	layout := NewLayout(0)
+	layout.Styles = formatterStyles(this.fmt)
+	if len(width) > 0 && width[0] > 0 {
+		layout.Width = width[0]
+	}
+	if this.tableStyle != nil {
+		layout.Style = CopyTableStyle(this.tableStyle)
+	}
+	return layout
+}
+
+func (this *DefaultOutput) Markdown(src string) {
+	this.io.Write([]byte(this.fmt.Format(RenderMarkdown(src, termWidthCurrent(), formatterStyles(this.fmt)) + "\n")))
+}
+
+func (this *DefaultOutput) Pager() io.WriteCloser {
+	return NewPager(this.io)
+}
+
 func (this *DefaultOutput) Printf(msg string, args ...interface{}) {
 	this.io.Write([]byte(this.Sprintf(msg, args...)))
 }
@@ -108,15 +176,28 @@ func (this *DefaultOutput) Sprintf(msg string, args ...interface{}) string {
 }
 
 func (this *DefaultOutput) Table(headers []string, style ...*TableStyle) *Table {
-	if len(style) == 0 {
-		style = []*TableStyle{NewDefaultTableStyle()}
-	}
-	style[0].HeaderRenderer = DefaultOutputTableHeaderRenderer(this)
-	style[0].ContentRenderer = DefaultOutputTableContentRenderer(this)
-	table := NewTable(headers, style...)
+	table := NewTable(headers, this.resolveTableStyle(style...))
 	return table
 }
 
+func (this *DefaultOutput) TableStream(headers []string, style ...*TableStyle) *TableStream {
+	return NewTableStream(this.io, headers, this.resolveTableStyle(style...))
+}
+
 func (this *DefaultOutput) Writer() io.Writer {
 	return this.io
 }
+
+func (this *DefaultOutput) resolveTableStyle(style ...*TableStyle) *TableStyle {
+	if len(style) == 0 {
+		if this.tableStyle != nil {
+			style = []*TableStyle{CopyTableStyle(this.tableStyle)}
+		} else {
+			style = []*TableStyle{NewDefaultTableStyle()}
+		}
+	}
+	style[0].HeaderRenderer = DefaultOutputTableHeaderRenderer(this)
+	style[0].ContentRenderer = DefaultOutputTableContentRenderer(this)
+	style[0].FooterRenderer = DefaultOutputTableFooterRenderer(this)
+	return style[0]
+}
@@ -0,0 +1,103 @@
+package clif
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Pager is a writer which buffers all output until it is closed. On close,
+// the output is piped through a pager program (see `PagerCommand`), if the
+// underlying writer is a terminal and the output has more lines than the
+// terminal is high. Otherwise the output is written to the underlying writer.
+type Pager struct {
+	out io.Writer
+	buf *bytes.Buffer
+}
+
+var (
+	// PagerEnv is the name of the environment variable containing the pager
+	// command line
+	PagerEnv = "PAGER"
+
+	// PagerDefault is the pager command line, used if `PagerEnv` is empty
+	PagerDefault = "less -R"
+
+	// PagerCommand returns the command line of the pager program
+	PagerCommand = func() string {
+		if cmd := strings.TrimSpace(os.Getenv(PagerEnv)); cmd != "" {
+			return cmd
+		}
+		return PagerDefault
+	}
+
+	// PagerShell is the shell command, which runs the pager command line
+	PagerShell = []string{"sh", "-c"}
+
+	// errPagerNotStarted is returned if the pager program could not be started
+	errPagerNotStarted = errors.New("Pager could not be started")
+)
+
+// NewPager constructs a new pager, writing to the given writer (if nil then
+// `os.Stdout` is used)
+func NewPager(out io.Writer) *Pager {
+	if out == nil {
+		out = os.Stdout
+	}
+	return &Pager{
+		out: out,
+		buf: bytes.NewBuffer(nil),
+	}
+}
+
+// Write adds to buffered output
+func (this *Pager) Write(p []byte) (int, error) {
+	return this.buf.Write(p)
+}
+
+// Close writes all buffered output, either through the pager or directly.
+// If the pager program fails to start, the output is written directly. If it
+// fails after it started, the error is returned.
+func (this *Pager) Close() error {
+	defer this.buf.Reset()
+	if file, ok := this.out.(*os.File); ok && this.Paging() {
+		if err := this.page(PagerCommand(), file); err != errPagerNotStarted {
+			return err
+		}
+	}
+	_, err := this.out.Write(this.buf.Bytes())
+	return err
+}
+
+// page pipes the buffered output through the pager command line, which is run
+// by `PagerShell`. Shells exit with 126 or 127 if the command can not be run.
+func (this *Pager) page(line string, file *os.File) error {
+	if strings.TrimSpace(line) == "" || len(PagerShell) == 0 {
+		return errPagerNotStarted
+	}
+	cmd := exec.Command(PagerShell[0], append(PagerShell[1:], line)...)
+	cmd.Stdin = bytes.NewReader(this.buf.Bytes())
+	cmd.Stdout = file
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return errPagerNotStarted
+	} else if err = cmd.Wait(); err != nil {
+		if exit, ok := err.(*exec.ExitError); ok && (exit.ExitCode() == 126 || exit.ExitCode() == 127) {
+			return errPagerNotStarted
+		}
+		return err
+	}
+	return nil
+}
+
+// Paging returns whether the currently buffered output would be paged
+func (this *Pager) Paging() bool {
+	file, ok := this.out.(*os.File)
+	if !ok || !TermIsTerminal(file.Fd()) {
+		return false
+	}
+	return bytes.Count(this.buf.Bytes(), []byte("\n")) >= termHeightCurrent()
+}
@@ -0,0 +1,54 @@
+package clif
+
+import (
+	"bytes"
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPager(t *testing.T) {
+	Convey("Pager buffers until closed", t, func() {
+		buf := bytes.NewBuffer(nil)
+		pager := NewPager(buf)
+		pager.Write([]byte(strings.Repeat("foo\n", TermHeightCurrent*2)))
+		So(buf.Len(), ShouldEqual, 0)
+		So(pager.Paging(), ShouldBeFalse)
+		So(pager.Close(), ShouldBeNil)
+		So(buf.String(), ShouldEqual, strings.Repeat("foo\n", TermHeightCurrent*2))
+	})
+	Convey("Pager does not page into non-terminal files", t, func() {
+		r, w, err := os.Pipe()
+		So(err, ShouldBeNil)
+		pager := NewPager(w)
+		pager.Write([]byte(strings.Repeat("foo\n", TermHeightCurrent*2)))
+		So(pager.Paging(), ShouldBeFalse)
+		So(pager.Close(), ShouldBeNil)
+		w.Close()
+		out, _ := ioutil.ReadAll(r)
+		So(string(out), ShouldEqual, strings.Repeat("foo\n", TermHeightCurrent*2))
+	})
+	Convey("Pager command from environment", t, func() {
+		So(PagerCommand(), ShouldNotBeEmpty)
+		orig := os.Getenv(PagerEnv)
+		defer os.Setenv(PagerEnv, orig)
+		os.Setenv(PagerEnv, "more -x")
+		So(PagerCommand(), ShouldEqual, "more -x")
+		os.Setenv(PagerEnv, "")
+		So(PagerCommand(), ShouldEqual, "less -R")
+	})
+	Convey("Pager command runs in shell", t, func() {
+		r, w, err := os.Pipe()
+		So(err, ShouldBeNil)
+		pager := NewPager(w)
+		pager.Write([]byte("foo\n"))
+		So(pager.page("cat | tr o 0", w), ShouldBeNil)
+		So(pager.page("not-existing-pager-command", w), ShouldEqual, errPagerNotStarted)
+		So(pager.page("cat; exit 3", w), ShouldNotBeNil)
+		w.Close()
+		out, _ := ioutil.ReadAll(r)
+		So(string(out), ShouldEqual, "f00\nfoo\n")
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/gosuri/uilive"
+	"io"
+	"os"
 	"sync"
 	"time"
 )
@@ -11,40 +13,170 @@ import (
 type (
 	ProgressBarPool interface {
 
+		// Collapse removes completed (finished or failed) bars and spinners
+		// after the given delay and counts them in a summary line instead. A
+		// negative delay disables collapsing, which is the default.
+		Collapse(delay time.Duration)
+
 		// Finish sets all progress to max and stops
 		Finish() chan bool
 
-		// Has return whether a bar with name exists or not
+		// Formatter sets the formatter, which renders the styles of failed
+		// progress bars and spinners
+		Formatter(f Formatter)
+
+		// Has return whether a bar or spinner with name exists or not
 		Has(bar string) bool
 
 		// Init adds and initializes a new, named progress bar of given size and returns it
 		Init(name string, size int) (ProgressBar, error)
 
+		// InitBytes adds and initializes a new, named progress bar, which counts
+		// bytes of given size, and returns it
+		InitBytes(name string, size int64) (ProgressBar, error)
+
+		// InitSpinner adds and initializes a new, named spinner and returns it
+		InitSpinner(name string) (ProgressSpinner, error)
+
+		// Interval sets the duration between status lines, if the output is
+		// not a terminal
+		Interval(interval time.Duration) error
+
+		// Mode sets how progress is rendered. Defaults to auto detection
+		// (terminal or status lines)
+		Mode(mode ProgressBarPoolMode) error
+
+		// Remove removes the bar or spinner with name. Can be called while
+		// running.
+		Remove(name string) error
+
 		// Start initializes rendering of all registered bars
 		Start()
 
-		// Style sets style of registered progress bars
+		// SpinnerStyle sets style of registered spinners, which do not use
+		// an individual style
+		SpinnerStyle(style *ProgressSpinnerStyle) error
+
+		// Style sets style of registered progress bars, which do not use an
+		// individual style
 		Style(style *ProgressBarStyle) error
 
+		// Total returns a bar, which aggregates the progress of all bars in
+		// the pool and is rendered below them. It is created on first call.
+		Total() ProgressBar
+
 		// With set's output width of registered progress bars
 		Width(width int) error
+
+		// Writer sets the output of the pool. Defaults to `os.Stdout`
+		Writer(out io.Writer) error
 	}
 
+	// ProgressBarPoolMode defines how a pool renders progress. See
+	// PROGRESS_BAR_POOL_*
+	ProgressBarPoolMode int
+
 	progressBarPool struct {
-		bars    map[string]ProgressBar
-		names   []string
-		mux     *sync.Mutex
-		style   *ProgressBarStyle
-		width   int
-		refresh time.Duration
-		writer  *uilive.Writer
-		started bool
-		finishc chan bool
+		bars         map[string]ProgressBar
+		spinners     map[string]ProgressSpinner
+		names        []string
+		mux          *sync.Mutex
+		formatter    Formatter
+		total        ProgressBar
+		collapse     time.Duration
+		completed    map[string]time.Time
+		summary      [2]int
+		retired      [2]int64
+		style        *ProgressBarStyle
+		spinnerStyle *ProgressSpinnerStyle
+		width        int
+		autoWidth    bool
+		refresh      time.Duration
+		interval     time.Duration
+		mode         ProgressBarPoolMode
+		out          io.Writer
+		writer       *uilive.Writer
+		started      bool
+		finishc      chan bool
 	}
 )
 
 const (
 	PROGRESS_BAR_DEFAULT_REFRESH = time.Millisecond * 50
+
+	// PROGRESS_BAR_DEFAULT_INTERVAL is the default duration between status
+	// lines, if the output is not a terminal
+	PROGRESS_BAR_DEFAULT_INTERVAL = time.Second * 10
+)
+
+const (
+	// PROGRESS_BAR_POOL_AUTO renders to the terminal, if the output is a
+	// terminal, and status lines otherwise
+	PROGRESS_BAR_POOL_AUTO ProgressBarPoolMode = iota
+
+	// PROGRESS_BAR_POOL_TERMINAL re-renders all bars in place
+	PROGRESS_BAR_POOL_TERMINAL
+
+	// PROGRESS_BAR_POOL_LINES prints plain text status lines periodically,
+	// eg for CI logs
+	PROGRESS_BAR_POOL_LINES
+
+	// PROGRESS_BAR_POOL_QUIET does not render anything
+	PROGRESS_BAR_POOL_QUIET
+)
+
+var (
+	// ProgressBarPoolRenderLine renders the status line of a progress bar, if
+	// the output is not a terminal, eg "foo: 45% (450/1000) ~00:12"
+	ProgressBarPoolRenderLine = func(name string, bar ProgressBar) string {
+		if label := bar.Label(); label != "" {
+			name = label
+		}
+		pos, size := bar.Position64(), bar.Size64()
+		percentage := 100
+		if size > 0 {
+			percentage = int(pos * 100 / size)
+		}
+		count := fmt.Sprintf("%d/%d", pos, size)
+		if bar.Bytes() {
+			count = RenderByteSize(pos) + "/" + RenderByteSize(size)
+		}
+		out := fmt.Sprintf("%s: %d%% (%s)", name, percentage, count)
+		if bar.Failed() {
+			out += " failed"
+		} else if estimate := bar.Estimate(); pos > 0 && !bar.Done() && estimate > 0 {
+			out += " ~" + renderClockDuration(estimate)
+		}
+		if message := bar.Message(); message != "" {
+			out += " " + message
+		}
+		return out
+	}
+
+	// ProgressBarPoolRenderSummary renders the summary line of collapsed bars
+	// and spinners, eg "12 completed, 1 failed"
+	ProgressBarPoolRenderSummary = func(completed, failed int) string {
+		out := fmt.Sprintf("%d completed", completed)
+		if failed > 0 {
+			out += fmt.Sprintf(", %d failed", failed)
+		}
+		return out
+	}
+
+	// ProgressBarPoolRenderSpinnerLine renders the status line of a spinner,
+	// if the output is not a terminal, eg "lock: Waiting for lock"
+	ProgressBarPoolRenderSpinnerLine = func(name string, spinner ProgressSpinner) string {
+		out := name + ":"
+		if message := spinner.Message(); message != "" {
+			out += " " + message
+		}
+		if spinner.Failed() {
+			out += " failed"
+		} else if spinner.Done() {
+			out += " done"
+		}
+		return out
+	}
 )
 
 func NewProgressBarPool(style ...*ProgressBarStyle) ProgressBarPool {
@@ -52,14 +184,23 @@ func NewProgressBarPool(style ...*ProgressBarStyle) ProgressBarPool {
 		style = []*ProgressBarStyle{ProgressBarStyleUtf8}
 	}
 	return &progressBarPool{
-		bars:    make(map[string]ProgressBar),
-		names:   make([]string, 0),
-		mux:     new(sync.Mutex),
-		refresh: PROGRESS_BAR_DEFAULT_REFRESH,
-		style:   style[0],
-		width:   TermWidthCurrent,
-		writer:  uilive.New(),
-		finishc: make(chan bool),
+		bars:         make(map[string]ProgressBar),
+		spinners:     make(map[string]ProgressSpinner),
+		names:        make([]string, 0),
+		mux:          new(sync.Mutex),
+		formatter:    NewDefaultFormatter(nil),
+		collapse:     -1,
+		completed:    make(map[string]time.Time),
+		refresh:      PROGRESS_BAR_DEFAULT_REFRESH,
+		interval:     PROGRESS_BAR_DEFAULT_INTERVAL,
+		mode:         PROGRESS_BAR_POOL_AUTO,
+		out:          os.Stdout,
+		style:        style[0],
+		spinnerStyle: ProgressSpinnerStyleUtf8,
+		width:        termWidthCurrent(),
+		autoWidth:    true,
+		writer:       uilive.New(),
+		finishc:      make(chan bool),
 	}
 }
 
@@ -77,7 +218,7 @@ func (this *progressBarPool) Increase(bar string, amount int) error {
 func (this *progressBarPool) Init(name string, size int) (ProgressBar, error) {
 	this.mux.Lock()
 	defer this.mux.Unlock()
-	if _, exist := this.bars[name]; exist {
+	if this.has(name) {
 		return nil, fmt.Errorf("Progress bar with name \"%s\" does already exist", name)
 	}
 	Dbg("Create new bar: %s (size: %d)", name, size)
@@ -88,10 +229,137 @@ func (this *progressBarPool) Init(name string, size int) (ProgressBar, error) {
 	return this.bars[name], nil
 }
 
+func (this *progressBarPool) InitBytes(name string, size int64) (ProgressBar, error) {
+	bar, err := this.Init(name, 1)
+	if err != nil {
+		return nil, err
+	}
+	bar.SetSize64(size).SetBytes(true).SetEstimator(NewProgressBarWindowEstimator(ProgressBarRateWindow))
+	return bar, nil
+}
+
+func (this *progressBarPool) InitSpinner(name string) (ProgressSpinner, error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.has(name) {
+		return nil, fmt.Errorf("Progress bar with name \"%s\" does already exist", name)
+	}
+	Dbg("Create new spinner: %s", name)
+	this.names = append(this.names, name)
+	this.spinners[name] = NewProgressSpinner()
+	this.spinners[name].SetStyle(this.spinnerStyle)
+	this.spinners[name].SetRenderWidth(this.width)
+	return this.spinners[name], nil
+}
+
+func (this *progressBarPool) Collapse(delay time.Duration) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.collapse = delay
+}
+
+func (this *progressBarPool) Remove(name string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if !this.has(name) {
+		return fmt.Errorf("Progress bar with name \"%s\" does not exist", name)
+	}
+	this.retire(name)
+	return nil
+}
+
+func (this *progressBarPool) Total() ProgressBar {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.total == nil {
+		this.total = NewProgressBar(1)
+		this.total.SetStyle(this.style)
+		this.total.SetRenderWidth(this.width)
+		this.total.SetLabel("Total")
+	}
+	return this.total
+}
+
+// retire removes a bar or spinner. The progress made by removed bars remains
+// counted in the total.
+func (this *progressBarPool) retire(name string) {
+	if bar, ok := this.bars[name]; ok {
+		pos := bar.Position64()
+		this.retired[0] += pos
+		this.retired[1] += pos
+		delete(this.bars, name)
+	} else {
+		delete(this.spinners, name)
+	}
+	delete(this.completed, name)
+	for idx, n := range this.names {
+		if n == name {
+			this.names = append(this.names[0:idx], this.names[idx+1:]...)
+			break
+		}
+	}
+}
+
+// collapseCompleted retires all bars and spinners, which are completed for
+// longer than the collapse delay
+func (this *progressBarPool) collapseCompleted() {
+	if this.collapse < 0 {
+		return
+	}
+	now := time.Now()
+	for _, name := range append([]string{}, this.names...) {
+		var done, failed bool
+		if bar, ok := this.bars[name]; ok {
+			failed = bar.Failed()
+			done = failed || bar.Done()
+		} else {
+			spinner := this.spinners[name]
+			done, failed = spinner.Done(), spinner.Failed()
+		}
+		if !done {
+			delete(this.completed, name)
+			continue
+		}
+		at, ok := this.completed[name]
+		if !ok {
+			this.completed[name] = now
+			at = now
+		}
+		if now.Sub(at) >= this.collapse {
+			if failed {
+				this.summary[1]++
+			} else {
+				this.summary[0]++
+			}
+			this.retire(name)
+		}
+	}
+}
+
+// updateTotal sets size and position of the total bar
+func (this *progressBarPool) updateTotal() {
+	if this.total == nil {
+		return
+	}
+	pos, size := this.retired[0], this.retired[1]
+	for _, bar := range this.bars {
+		pos += bar.Position64()
+		size += bar.Size64()
+	}
+	this.total.SetSize64(size)
+	this.total.Set64(pos)
+}
+
 func (this *progressBarPool) Has(bar string) bool {
 	this.mux.Lock()
 	defer this.mux.Unlock()
-	if _, exist := this.bars[bar]; exist {
+	return this.has(bar)
+}
+
+func (this *progressBarPool) has(name string) bool {
+	if _, exist := this.bars[name]; exist {
+		return true
+	} else if _, exist := this.spinners[name]; exist {
 		return true
 	}
 	return false
@@ -102,7 +370,7 @@ func (this *progressBarPool) Increment(bar string, bars ...string) error {
 	defer this.mux.Unlock()
 	names := append([]string{bar}, bars...)
 	for _, name := range names {
-		if !this.Has(name) {
+		if _, exist := this.bars[name]; !exist {
 			return fmt.Errorf("Progress bar with name \"%s\" does not exist", name)
 		}
 	}
@@ -122,15 +390,74 @@ func (this *progressBarPool) Set(bar string, pos int) error {
 	return pb.Set(pos)
 }
 
+func (this *progressBarPool) Interval(interval time.Duration) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.started {
+		return fmt.Errorf("Cannot set interval after start")
+	}
+	this.interval = interval
+	return nil
+}
+
+func (this *progressBarPool) Mode(mode ProgressBarPoolMode) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.started {
+		return fmt.Errorf("Cannot set mode after start")
+	}
+	this.mode = mode
+	return nil
+}
+
+func (this *progressBarPool) Writer(out io.Writer) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.started {
+		return fmt.Errorf("Cannot set writer after start")
+	}
+	this.out = out
+	return nil
+}
+
+// currentMode resolves auto detection of the mode
+func (this *progressBarPool) currentMode() ProgressBarPoolMode {
+	if this.mode != PROGRESS_BAR_POOL_AUTO {
+		return this.mode
+	}
+	if file, ok := this.out.(*os.File); ok && TermIsTerminal(file.Fd()) {
+		return PROGRESS_BAR_POOL_TERMINAL
+	}
+	return PROGRESS_BAR_POOL_LINES
+}
+
 func (this *progressBarPool) Start() {
 	this.mux.Lock()
 	defer this.mux.Unlock()
 	if !this.started {
 		this.started = true
-		this.writer.Start()
+		mode := this.currentMode()
+		refresh := this.refresh
+		unsubscribe := func() {}
+		if mode == PROGRESS_BAR_POOL_TERMINAL {
+			this.writer.Out = this.out
+			this.writer.Start()
+
+			// re-flow bars on terminal resize, unless width was set explicitly
+			if width := termWidthCurrent(); this.autoWidth && this.width != width {
+				this.width = width
+				this.setWidth(this.width)
+			}
+			unsubscribe = TermOnResize(func(width, height int) {
+				this.resize(width)
+			})
+		} else {
+			refresh = this.interval
+		}
 
 		go func() {
 			defer func() {
+				unsubscribe()
 				this.mux.Lock()
 				defer this.mux.Unlock()
 				close(this.finishc)
@@ -139,9 +466,18 @@ func (this *progressBarPool) Start() {
 				for _, bar := range this.bars {
 					bar.Reset()
 				}
+				for _, spinner := range this.spinners {
+					spinner.Reset()
+				}
+				if this.total != nil {
+					this.total.Reset()
+				}
+				this.completed = make(map[string]time.Time)
+				this.summary = [2]int{}
+				this.retired = [2]int64{}
 			}()
 
-			tick := time.NewTicker(this.refresh)
+			tick := time.NewTicker(refresh)
 			defer tick.Stop()
 
 			for {
@@ -149,37 +485,120 @@ func (this *progressBarPool) Start() {
 
 				// finished
 				case <- this.finishc:
-					this.render()
-					<-time.After(time.Millisecond * 5) // to assure rendering is written fully..
-					this.writer.Stop()
+					this.render(mode)
+					if mode == PROGRESS_BAR_POOL_TERMINAL {
+						<-time.After(time.Millisecond * 5) // to assure rendering is written fully..
+						this.writer.Stop()
+					}
 					return
 
 				// tick:
 				case <-tick.C:
 					//this.writer.Flush()
-					this.render()
+					this.render(mode)
 				}
 			}
 		}()
 	}
 }
 
-func (this *progressBarPool) render() {
+func (this *progressBarPool) render(mode ProgressBarPoolMode) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.collapseCompleted()
+	this.updateTotal()
+	switch mode {
+	case PROGRESS_BAR_POOL_TERMINAL:
+		this.renderTerminal()
+	case PROGRESS_BAR_POOL_LINES:
+		this.renderLines()
+	}
+}
+
+func (this *progressBarPool) renderLines() {
+	buf := bytes.NewBuffer(nil)
+	if this.summary[0]+this.summary[1] > 0 {
+		buf.WriteString(ProgressBarPoolRenderSummary(this.summary[0], this.summary[1]) + "\n")
+	}
+	for _, name := range this.names {
+		if bar, ok := this.bars[name]; ok {
+			buf.WriteString(ProgressBarPoolRenderLine(name, bar) + "\n")
+		} else {
+			buf.WriteString(ProgressBarPoolRenderSpinnerLine(name, this.spinners[name]) + "\n")
+		}
+	}
+	if this.total != nil {
+		buf.WriteString(ProgressBarPoolRenderLine("total", this.total) + "\n")
+	}
+	this.out.Write(buf.Bytes())
+}
+
+func (this *progressBarPool) renderTerminal() {
 	buf := bytes.NewBuffer(nil)
+	this.alignLabels()
+	if this.summary[0]+this.summary[1] > 0 {
+		buf.WriteString(ProgressBarPoolRenderSummary(this.summary[0], this.summary[1]) + "\n")
+	}
 	for _, name := range this.names {
-		buf.WriteString(this.bars[name].Render() + "\n")
+		var rendered string
+		var failed bool
+		if bar, ok := this.bars[name]; ok {
+			rendered, failed = bar.Render(), bar.Failed()
+		} else {
+			spinner := this.spinners[name]
+			rendered, failed = spinner.Render(), spinner.Failed()
+		}
+		if failed && this.formatter != nil {
+			rendered = this.formatter.Format("<error>") + rendered + this.formatter.Format("<reset>")
+		}
+		buf.WriteString(rendered + "\n")
+	}
+	if this.total != nil {
+		buf.WriteString(this.total.Render() + "\n")
 	}
 	this.writer.Write(buf.Bytes())
 }
 
+// alignLabels sets the label width of all bars to the longest label
+func (this *progressBarPool) alignLabels() {
+	bars := make([]ProgressBar, 0, len(this.bars)+1)
+	for _, bar := range this.bars {
+		bars = append(bars, bar)
+	}
+	if this.total != nil {
+		bars = append(bars, this.total)
+	}
+	width := 0
+	for _, bar := range bars {
+		if l := StringLength(bar.Label()); l > width {
+			width = l
+		}
+	}
+	for _, bar := range bars {
+		bar.SetLabelWidth(width)
+	}
+}
+
 func (this *progressBarPool) Finish() chan bool {
 	this.mux.Lock()
-	defer this.mux.Unlock()
-	for _, name := range this.names {
-		this.bars[name].Finish()
+	for _, bar := range this.bars {
+		bar.Finish()
+	}
+	for _, spinner := range this.spinners {
+		spinner.Finish()
 	}
-	this.finishc <- true
-	return this.finishc
+	finishc := this.finishc
+	this.mux.Unlock()
+
+	// rendering requires the lock
+	finishc <- true
+	return finishc
+}
+
+func (this *progressBarPool) Formatter(f Formatter) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.formatter = f
 }
 
 func (this *progressBarPool) Style(style *ProgressBarStyle) error {
@@ -188,10 +607,30 @@ func (this *progressBarPool) Style(style *ProgressBarStyle) error {
 	if this.started {
 		return fmt.Errorf("Cannot set style after start")
 	}
-	this.style = style
 	for _, bar := range this.bars {
-		bar.SetStyle(style)
+		if bar.Style() == this.style {
+			bar.SetStyle(style)
+		}
+	}
+	if this.total != nil && this.total.Style() == this.style {
+		this.total.SetStyle(style)
+	}
+	this.style = style
+	return nil
+}
+
+func (this *progressBarPool) SpinnerStyle(style *ProgressSpinnerStyle) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.started {
+		return fmt.Errorf("Cannot set style after start")
+	}
+	for _, spinner := range this.spinners {
+		if spinner.Style() == this.spinnerStyle {
+			spinner.SetStyle(style)
+		}
 	}
+	this.spinnerStyle = style
 	return nil
 }
 
@@ -201,9 +640,43 @@ func (this *progressBarPool) Width(width int) error {
 	if this.started {
 		return fmt.Errorf("Cannot set width after start")
 	}
+	this.autoWidth = false
+	this.width = width
+	this.setWidth(width)
+	return nil
+}
+
+func (this *progressBarPool) resize(width int) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if !this.autoWidth {
+		return
+	}
 	this.width = width
+	this.setWidth(width)
+}
+
+func (this *progressBarPool) setWidth(width int) {
 	for _, bar := range this.bars {
 		bar.SetRenderWidth(width)
 	}
-	return nil
+	if this.total != nil {
+		this.total.SetRenderWidth(width)
+	}
+	for _, spinner := range this.spinners {
+		spinner.SetRenderWidth(width)
+	}
+}
+
+// renderClockDuration renders a duration like a clock, eg "00:12" or "01:02:03"
+func renderClockDuration(dur time.Duration) string {
+	secs := int(dur.Seconds())
+	if secs < 0 {
+		secs = 0
+	}
+	h, m, sec := secs/3600, (secs/60)%60, secs%60
+	if h > 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", h, m, sec)
+	}
+	return fmt.Sprintf("%02d:%02d", m, sec)
 }
@@ -2,6 +2,7 @@ package clif
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 	"time"
@@ -10,21 +11,56 @@ import (
 type (
 	ProgressBar interface {
 
+		// Bytes returns whether the progress bar counts bytes
+		Bytes() bool
+
 		// Done returns whether progress bar is done (position == size)
 		Done() bool
 
+		// Estimate returns the expected remaining duration
+		Estimate() time.Duration
+
+		// Fail stops the progress bar at it's current position and marks it as failed
+		Fail()
+
+		// Failed returns whether the progress bar is failed
+		Failed() bool
+
 		// Finish sets progress to max and
 		Finish()
 
 		// Increase adds given amount to progress
 		Increase(amount int) error
 
+		// Increase64 adds given amount to progress
+		Increase64(amount int64) error
+
 		// Increment increases progress by one
 		Increment() error
 
+		// Label returns the label rendered in front of the progress bar
+		Label() string
+
+		// Message returns the status message rendered below the progress bar
+		Message() string
+
 		// Position returns the progress position
 		Position() int
 
+		// Position64 returns the progress position
+		Position64() int64
+
+		// Estimator returns the estimator, which calculates the rate
+		Estimator() ProgressBarEstimator
+
+		// Rate returns the current progress per second, as calculated by the
+		// estimator
+		Rate() float64
+
+		// Reader returns a reader, which increases progress by the amount of
+		// bytes read from the given reader. The size grows with longer streams.
+		Reader(r io.Reader) io.Reader
+
 		// Render returns the rendered progress bar in it's current position
 		Render() string
 
@@ -37,25 +73,77 @@ type (
 		// Set moves the progress to given position
 		Set(position int) error
 
+		// Set64 moves the progress to given position
+		Set64(position int64) error
+
+		// SetBytes sets whether the progress bar counts bytes, which renders
+		// human readable sizes and transfer rates
+		SetBytes(v bool) ProgressBar
+
+		// SetEstimator sets the estimator, which calculates the rate and the
+		// remaining time
+		SetEstimator(estimator ProgressBarEstimator) ProgressBar
+
+		// SetLabel sets the label rendered in front of the progress bar
+		SetLabel(label string) ProgressBar
+
+		// SetLabelWidth sets the minimal width of the label column, so that bars
+		// of a pool are aligned
+		SetLabelWidth(v int) ProgressBar
+
+		// SetMessage sets the status message rendered below the progress bar
+		SetMessage(message string) ProgressBar
+
 		// SetRenderWidth sets total width of rendered output to given amount of characters (runes)
 		SetRenderWidth(v int) ProgressBar
 
 		// SetSize sets the total size of the progress bar
 		SetSize(v int) ProgressBar
 
+		// SetSize64 sets the total size of the progress bar
+		SetSize64(v int64) ProgressBar
+
 		// SetStyle sets the style of the progress bar
 		SetStyle(style *ProgressBarStyle) ProgressBar
 
+		// Size returns the total size of the progress bar
+		Size() int
+
+		// Size64 returns the total size of the progress bar
+		Size64() int64
+
 		// Style sets the
 		Style() *ProgressBarStyle
+
+		// Writer returns a writer, which increases progress by the amount of
+		// bytes written to the given writer. The size grows with longer streams.
+		Writer(w io.Writer) io.Writer
 	}
 
 	ProgressBarSimple struct {
 		// size is total length of progress
-		size int
+		size int64
 
 		// position is current location in progress
-		position int
+		position int64
+
+		// bytes is true if progress is counted in bytes
+		bytes bool
+
+		// estimator calculates the rate and the remaining time
+		estimator ProgressBarEstimator
+
+		// failed is true if the progress bar has been failed
+		failed bool
+
+		// label is rendered in front of the progress bar
+		label string
+
+		// labelWidth is the minimal width of the label column
+		labelWidth int
+
+		// message is rendered below the progress bar
+		message string
 
 		// RenderWidth is the max size of characters
 		renderWidth int
@@ -71,12 +159,26 @@ type (
 
 		mux *sync.Mutex
 	}
+
+	progressBarReader struct {
+		reader io.Reader
+		bar    *ProgressBarSimple
+	}
+
+	progressBarWriter struct {
+		writer io.Writer
+		bar    *ProgressBarSimple
+	}
 )
 
 var (
 	// PbOutOfBoundError is returned when increasing, adding or setting the position
 	// with a value which is below 0 or beyond size of the progress bar
 	PbOutOfBoundError = fmt.Errorf("Position is out of bounds")
+
+	// PbFailedError is returned when increasing, adding or setting the position
+	// of a failed progress bar
+	PbFailedError = fmt.Errorf("Progress bar is failed")
 )
 
 func NewProgressBar(size int) *ProgressBarSimple {
@@ -89,13 +191,27 @@ func NewProgressBar(size int) *ProgressBarSimple {
 	}
 
 	return &ProgressBarSimple{
-		size:        size,
-		renderWidth: TermWidthCurrent,
+		size:        int64(size),
+		renderWidth: termWidthCurrent(),
 		mux:         new(sync.Mutex),
 		style:       ProgressBarStyleUtf8,
+		estimator:   NewProgressBarLinearEstimator(),
 	}
 }
 
+// NewBytesProgressBar constructs a progress bar, which counts bytes of the
+// given total size. The transfer rate is averaged over `ProgressBarRateWindow`.
+func NewBytesProgressBar(size int64) *ProgressBarSimple {
+	bar := NewProgressBar(1)
+	bar.SetSize64(size).SetBytes(true).SetEstimator(NewProgressBarWindowEstimator(ProgressBarRateWindow))
+	return bar
+}
+
+// Bytes returns whether progress is counted in bytes
+func (this *ProgressBarSimple) Bytes() bool {
+	return this.bytes
+}
+
 // Done returns bool whether progress bar is done (at 100%)
 func (this *ProgressBarSimple) Done() bool {
 	this.mux.Lock()
@@ -107,21 +223,55 @@ func (this *ProgressBarSimple) done() bool {
 	return this.position == this.size
 }
 
-// Finish ends progress by skipping to the end
+// Estimate returns the expected remaining duration
+func (this *ProgressBarSimple) Estimate() time.Duration {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.size == 0 {
+		return time.Duration(0)
+	}
+	return this.estimate(this.size)
+}
+
+// Fail stops progress at the current position and marks the bar as failed
+func (this *ProgressBarSimple) Fail() {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.failed {
+		return
+	}
+	this.setTimes()
+	this.failed = true
+	if this.stopped.IsZero() {
+		this.stopped = time.Now()
+	}
+}
+
+// Failed returns whether the bar is failed
+func (this *ProgressBarSimple) Failed() bool {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return this.failed
+}
+
+// Finish ends progress by skipping to the end. Failed bars are not changed.
 func (this *ProgressBarSimple) Finish() {
-	this.Set(this.size)
+	if this.Failed() {
+		return
+	}
+	this.Set64(this.size)
 }
 
 // Add increases progress by given amount
 func (this *ProgressBarSimple) Increase(amount int) error {
+	return this.Increase64(int64(amount))
+}
+
+// Increase64 increases progress by given amount
+func (this *ProgressBarSimple) Increase64(amount int64) error {
 	this.mux.Lock()
 	defer this.mux.Unlock()
-	if this.position+amount > this.size {
-		return PbOutOfBoundError
-	}
-	this.position += amount
-	this.setTimes()
-	return nil
+	return this.increase(amount, false)
 }
 
 // Increase adds a one to progress
@@ -129,17 +279,65 @@ func (this *ProgressBarSimple) Increment() error {
 	return this.Increase(1)
 }
 
+// Label returns the label rendered in front of the bar
+func (this *ProgressBarSimple) Label() string {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return this.label
+}
+
+// Message returns the status message rendered below the bar
+func (this *ProgressBarSimple) Message() string {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return this.message
+}
+
 // Position returns the current position
 func (this *ProgressBarSimple) Position() int {
+	return int(this.Position64())
+}
+
+// Position64 returns the current position
+func (this *ProgressBarSimple) Position64() int64 {
 	this.mux.Lock()
 	defer this.mux.Unlock()
 	return this.position
 }
 
+// Estimator returns the estimator, which calculates the rate
+func (this *ProgressBarSimple) Estimator() ProgressBarEstimator {
+	return this.estimator
+}
+
+// Rate returns the progress per second, as calculated by the estimator.
+// Finished bars return the average over the whole progress.
+func (this *ProgressBarSimple) Rate() float64 {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return this.rate()
+}
+
+// Reader returns a reader, which increases progress by the amount of bytes
+// read from the given reader. The size grows with longer streams.
+func (this *ProgressBarSimple) Reader(r io.Reader) io.Reader {
+	return &progressBarReader{reader: r, bar: this}
+}
+
+// stream adds the amount of bytes read or written by a wrapped reader or writer.
+// Streams which exceed the size increase the size, failed bars are not changed.
+func (this *ProgressBarSimple) stream(amount int64) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.increase(amount, true)
+}
+
 func (this *ProgressBarSimple) Reset() {
 	this.mux.Lock()
 	defer this.mux.Unlock()
 	this.position = 0
+	this.failed = false
+	this.estimator.Reset()
 	this.started = time.Time{}
 	this.stopped = time.Time{}
 }
@@ -154,15 +352,17 @@ func (this *ProgressBarSimple) Render() string {
 	if size == 0 {
 		size = 1
 	}
-	percentage := float32(this.position) * 100 / float32(size)
+	percentage := float32(float64(this.position) * 100 / float64(size))
 	infoPrefix := this.buildProgressInfo(percentage, size, PROGRESS_BAR_ADDON_PREPEND)
 	infoSuffix := this.buildProgressInfo(percentage, size, PROGRESS_BAR_ADDON_APPEND)
-	infoSize := StringLength(infoPrefix) + StringLength(infoSuffix) +
+	label := this.renderLabel()
+	infoSize := StringLength(infoPrefix) + StringLength(infoSuffix) + StringLength(label) +
 	StringLength(string(this.style.LeftBorder)) + StringLength(string(this.style.RightBorder))
 	width := this.renderWidth - infoSize
 	if width == 0 {
 		width = 1
 	}
+	out += label
 	out += infoPrefix
 	out += string(this.style.LeftBorder)
 	progress := int(percentage * float32(width) / 100)
@@ -188,50 +388,142 @@ func (this *ProgressBarSimple) Render() string {
 	out += string(this.style.RightBorder)
 	out += infoSuffix
 
+	if this.message != "" {
+		indent := StringLength(label)
+		width := this.renderWidth - indent
+		if width < 0 {
+			width = 0
+		}
+		out += "\n" + strings.Repeat(" ", indent) + TruncateString(this.message, width, "…")
+	}
+
 	return out
 }
 
 // Render returns rendered progress bar in current progress position
 func (this *ProgressBarSimple) RenderWidth() int {
+	this.mux.Lock()
+	defer this.mux.Unlock()
 	return this.renderWidth
 }
 
 // Set moves progress to given position (must be between 0 and size)
 func (this *ProgressBarSimple) Set(position int) error {
+	return this.Set64(int64(position))
+}
+
+// Set64 moves progress to given position (must be between 0 and size)
+func (this *ProgressBarSimple) Set64(position int64) error {
 	this.mux.Lock()
 	defer this.mux.Unlock()
+	if this.failed {
+		return PbFailedError
+	}
 	if position < 0 || position > this.size {
 		return PbOutOfBoundError
 	}
 	this.position = position
 	this.setTimes()
+	this.estimator.Update(this.position, time.Now().Sub(this.started))
 	return nil
 }
 
+// SetBytes is builder method to set whether progress is counted in bytes
+func (this *ProgressBarSimple) SetBytes(v bool) ProgressBar {
+	this.bytes = v
+	return this
+}
+
+// SetLabel is builder method to set the label rendered in front of the bar
+func (this *ProgressBarSimple) SetLabel(label string) ProgressBar {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.label = label
+	return this
+}
+
+// SetLabelWidth is builder method to set the minimal width of the label, so
+// that multiple bars can be aligned
+func (this *ProgressBarSimple) SetLabelWidth(v int) ProgressBar {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.labelWidth = v
+	return this
+}
+
+// SetMessage is builder method to set the status message rendered below the
+// bar, eg "uploading foo.tar"
+func (this *ProgressBarSimple) SetMessage(message string) ProgressBar {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.message = message
+	return this
+}
+
+// SetEstimator is builder method to set the estimator, which calculates the
+// rate and the remaining time (defaults to `NewProgressBarLinearEstimator()`)
+func (this *ProgressBarSimple) SetEstimator(estimator ProgressBarEstimator) ProgressBar {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.estimator = estimator
+	return this
+}
+
 // SetRenderWidth is builder method to set render width (defaults to PB_DEFAULT_RENDER_WIDTH)
 func (this *ProgressBarSimple) SetRenderWidth(v int) ProgressBar {
+	this.mux.Lock()
+	defer this.mux.Unlock()
 	this.renderWidth = v
 	return this
 }
 
 // SetSize is builder method to set size (i.e. max length) of progress
 func (this *ProgressBarSimple) SetSize(v int) ProgressBar {
+	return this.SetSize64(int64(v))
+}
+
+// SetSize64 is builder method to set size (i.e. max length) of progress
+func (this *ProgressBarSimple) SetSize64(v int64) ProgressBar {
+	this.mux.Lock()
+	defer this.mux.Unlock()
 	this.size = v
 	return this
 }
 
 // SetStyle sets the rendering (output) style (i.e. the characters used to print the progress bar)
 func (this *ProgressBarSimple) SetStyle(v *ProgressBarStyle) ProgressBar {
+	this.mux.Lock()
+	defer this.mux.Unlock()
 	this.style = v
 	return this
 }
 
+// Size returns the total size of progress
+func (this *ProgressBarSimple) Size() int {
+	return int(this.Size64())
+}
+
+// Size64 returns the total size of progress
+func (this *ProgressBarSimple) Size64() int64 {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return this.size
+}
+
 // Style returns the currently used style
 func (this *ProgressBarSimple) Style() *ProgressBarStyle {
+	this.mux.Lock()
+	defer this.mux.Unlock()
 	return this.style
 }
 
-func (this *ProgressBarSimple) buildProgressInfo(percentage float32, size int, pos progressBarAddon) string {
+// Writer returns a writer, which increases progress by the amount of bytes
+// written to the given writer. The size grows with longer streams.
+func (this *ProgressBarSimple) Writer(w io.Writer) io.Writer {
+	return &progressBarWriter{writer: w, bar: this}
+}
+
+func (this *ProgressBarSimple) buildProgressInfo(percentage float32, size int64, pos progressBarAddon) string {
 	// count, elapsed, estimate, percentage
 	out := []string{"", "", "", ""}
 
@@ -247,18 +539,62 @@ func (this *ProgressBarSimple) buildProgressInfo(percentage float32, size int, p
 	if this.style.Percentage == pos {
 		out[3] = this.renderPercentage(percentage)
 	}
+	rate := ""
+	if this.style.Rate == pos {
+		rate = this.renderRate()
+	}
 
 	if pos == PROGRESS_BAR_ADDON_APPEND {
-		return this.style.RenderSuffix(out[0], out[1], out[2], out[3])
+		info := this.style.RenderSuffix(out[0], out[1], out[2], out[3])
+		if rate != "" {
+			info += " " + rate
+		}
+		return info
 	} else {
-		return this.style.RenderPrefix(out[0], out[1], out[2], out[3])
+		info := this.style.RenderPrefix(out[0], out[1], out[2], out[3])
+		if rate != "" {
+			info = rate + " " + info
+		}
+		return info
 	}
 }
 
+// increase adds given amount to progress. If grow is true, the size is
+// increased instead of failing with an out of bound error.
+func (this *ProgressBarSimple) increase(amount int64, grow bool) error {
+	if this.failed {
+		return PbFailedError
+	}
+	if this.position+amount > this.size {
+		if !grow {
+			return PbOutOfBoundError
+		}
+		this.size = this.position + amount
+	}
+	this.position += amount
+	this.setTimes()
+	this.estimator.Update(this.position, time.Now().Sub(this.started))
+	return nil
+}
+
+func (this *ProgressBarSimple) renderLabel() string {
+	width := this.labelWidth
+	if l := StringLength(this.label); l > width {
+		width = l
+	}
+	if width == 0 {
+		return ""
+	}
+	return this.label + strings.Repeat(" ", width-StringLength(this.label)) + " "
+}
+
 func (this *ProgressBarSimple) setTimes() {
 	if this.started.Year() <= 1 {
 		this.started = time.Now()
 	}
+	if this.failed {
+		return
+	}
 	if this.size == this.position {
 		if this.stopped.Year() <= 1 {
 			this.stopped = time.Now()
@@ -268,9 +604,21 @@ func (this *ProgressBarSimple) setTimes() {
 	}
 }
 
+func (this *ProgressBarSimple) rate() float64 {
+	if this.done() || this.failed {
+		if elapsed := this.stopped.Sub(this.started).Seconds(); elapsed > 0 {
+			return float64(this.position) / elapsed
+		}
+		return 0
+	} else if this.started.IsZero() {
+		return 0
+	}
+	return this.estimator.Rate(time.Now().Sub(this.started))
+}
+
 func (this *ProgressBarSimple) renderElapsed() string {
 	var duration time.Duration
-	if this.done() {
+	if this.done() || this.failed {
 		duration = this.stopped.Sub(this.started)
 	} else {
 		duration = time.Now().Sub(this.started)
@@ -278,27 +626,42 @@ func (this *ProgressBarSimple) renderElapsed() string {
 	return this.style.RenderElapsed(duration, this)
 }
 
-func (this *ProgressBarSimple) renderCount(size int) string {
-	return this.style.RenderCount(this.position, size, this)
+func (this *ProgressBarSimple) renderCount(size int64) string {
+	if this.bytes {
+		render := this.style.RenderBytes
+		if render == nil {
+			render = ProgressBarDefaultRenderBytes
+		}
+		return render(this.position, size, this)
+	}
+	return this.style.RenderCount(int(this.position), int(size), this)
+}
+
+func (this *ProgressBarSimple) renderRate() string {
+	render := this.style.RenderRate
+	if render == nil {
+		render = ProgressBarDefaultRenderRate
+	}
+	return render(this.rate(), this)
 }
 
 func (this *ProgressBarSimple) renderPercentage(percentage float32) string {
 	return this.style.RenderPercentage(percentage, this)
 }
 
-func (this *ProgressBarSimple) renderEstimate(size int) string {
-	if this.done() {
-		return this.style.RenderEstimate(time.Duration(0), this)
+func (this *ProgressBarSimple) renderEstimate(size int64) string {
+	return this.style.RenderEstimate(this.estimate(size), this)
+}
+
+func (this *ProgressBarSimple) estimate(size int64) time.Duration {
+	if this.done() || this.failed {
+		return time.Duration(0)
 	}
-	duration := time.Now().Sub(this.started)
-	var expected uint64
-	if this.position > 0 {
-		// dur / pos = total / size
-		// total = dur * size / pos
-		expected = uint64(float64(duration.Nanoseconds()) * float64(size) / float64(this.position))
+	rate := this.rate()
+	if rate <= 0 {
+		return time.Duration(0)
 	}
-	remaining := time.Duration(expected - uint64(duration.Nanoseconds()))
-	return this.style.RenderEstimate(remaining, this)
+	return time.Duration(float64(size-this.position) / rate * float64(time.Second))
 }
 
 var (
@@ -334,6 +697,21 @@ func (this *ProgressBarSimple) renderFixedSizeDuration(dur time.Duration) string
 	}
 }
 
+// RenderByteSize renders the given amount of bytes human readable, eg "1.5MB"
+func RenderByteSize(size int64) string {
+	if size < 1024 {
+		return fmt.Sprintf("%dB", size)
+	}
+	units := []string{"B", "KB", "MB", "GB", "TB", "PB", "EB"}
+	value := float64(size)
+	idx := 0
+	for value >= 1024 && idx < len(units)-1 {
+		value /= 1024
+		idx++
+	}
+	return fmt.Sprintf("%.1f%s", value, units[idx])
+}
+
 func RenderFixedSizeDuration(dur time.Duration) string {
 	h := dur.Hours()
 	m := dur.Minutes()
@@ -364,3 +742,19 @@ func RenderFixedSizeDuration(dur time.Duration) string {
 		return fmt.Sprintf("%02ds%03d", int(s), int(ms))
 	}
 }
+
+func (this *progressBarReader) Read(p []byte) (int, error) {
+	n, err := this.reader.Read(p)
+	if n > 0 {
+		this.bar.stream(int64(n))
+	}
+	return n, err
+}
+
+func (this *progressBarWriter) Write(p []byte) (int, error) {
+	n, err := this.writer.Write(p)
+	if n > 0 {
+		this.bar.stream(int64(n))
+	}
+	return n, err
+}
@@ -1,7 +1,11 @@
 package clif
 
 import (
+	"bytes"
 	. "github.com/smartystreets/goconvey/convey"
+	"io"
+	"io/ioutil"
+	"strings"
 	"testing"
 	//"time"
 	"time"
@@ -112,3 +116,248 @@ func TestProgressBarRender(t *testing.T) {
 		})
 	})
 }
+
+func TestProgressBarPoolResize(t *testing.T) {
+	Convey("Progress bars re-flow on resize", t, func() {
+		pool := NewProgressBarPool().(*progressBarPool)
+		bar, _ := pool.Init("foo", 100)
+		pool.resize(50)
+		So(bar.RenderWidth(), ShouldEqual, 50)
+
+		Convey("Unless width was set explicitly", func() {
+			pool.Width(30)
+			pool.resize(60)
+			So(bar.RenderWidth(), ShouldEqual, 30)
+		})
+	})
+}
+
+func TestProgressBarLabelsAndMessages(t *testing.T) {
+	Convey("Rendering labels and messages", t, func() {
+		style := CloneProgressBarStyle(ProgressBarStyleAscii)
+		style.Count = PROGRESS_BAR_ADDON_OFF
+		style.Elapsed = PROGRESS_BAR_ADDON_OFF
+		style.Estimate = PROGRESS_BAR_ADDON_OFF
+		style.Percentage = PROGRESS_BAR_ADDON_APPEND
+		pb := NewProgressBar(100).SetStyle(style).SetRenderWidth(30).(*ProgressBarSimple)
+		pb.SetLabel("foo")
+
+		rendered := _testRenderProgressBar(pb, 50)
+		So(rendered, ShouldEqual, "foo [========>---------] 50.0%")
+
+		Convey("Labels are padded to label width", func() {
+			pb.SetLabelWidth(6)
+			So(_testRenderProgressBar(pb, 50), ShouldEqual, "foo    [======>--------] 50.0%")
+		})
+
+		Convey("Messages are rendered below", func() {
+			pb.SetMessage("uploading foo.tar from the build server")
+			So(pb.Render(), ShouldEqual, "foo [========>---------] 50.0%\n    uploading foo.tar from th…")
+		})
+	})
+}
+
+func TestProgressBarFail(t *testing.T) {
+	Convey("Failing progress bars", t, func() {
+		pb := NewProgressBar(100)
+		pb.Set(30)
+		pb.Fail()
+		So(pb.Failed(), ShouldBeTrue)
+		So(pb.Done(), ShouldBeFalse)
+
+		Convey("Keeps position on finish", func() {
+			pb.Finish()
+			So(pb.Position(), ShouldEqual, 30)
+		})
+
+		Convey("Does not change position", func() {
+			So(pb.Increment(), ShouldEqual, PbFailedError)
+			So(pb.Set(50), ShouldEqual, PbFailedError)
+			So(pb.Position(), ShouldEqual, 30)
+		})
+
+		Convey("Reset unsets failure", func() {
+			pb.Reset()
+			So(pb.Failed(), ShouldBeFalse)
+		})
+	})
+}
+
+func TestProgressBarPoolLabelsAndStyles(t *testing.T) {
+	Convey("Progress bar pools", t, func() {
+		pool := NewProgressBarPool().(*progressBarPool)
+		pool.Width(40)
+		foo, _ := pool.Init("foo", 10)
+		bar, _ := pool.Init("bar", 10)
+		foo.SetLabel("foo")
+		bar.SetLabel("barbaz")
+
+		Convey("Align labels", func() {
+			pool.alignLabels()
+			So(foo.Render()[0:7], ShouldEqual, "foo    ")
+			So(bar.Render()[0:7], ShouldEqual, "barbaz ")
+		})
+
+		Convey("Keep individual styles", func() {
+			style := CloneProgressBarStyle(ProgressBarStyleUtf8)
+			bar.SetStyle(style)
+			So(pool.Style(ProgressBarStyleAscii), ShouldBeNil)
+			So(foo.Style(), ShouldEqual, ProgressBarStyleAscii)
+			So(bar.Style(), ShouldEqual, style)
+		})
+	})
+}
+
+func TestProgressBarBytes(t *testing.T) {
+	Convey("Counting bytes", t, func() {
+		So(RenderByteSize(512), ShouldEqual, "512B")
+		So(RenderByteSize(1536), ShouldEqual, "1.5KB")
+		So(RenderByteSize(10*1024*1024), ShouldEqual, "10.0MB")
+
+		style := CloneProgressBarStyle(ProgressBarStyleAscii)
+		style.Count = PROGRESS_BAR_ADDON_PREPEND
+		style.Elapsed = PROGRESS_BAR_ADDON_OFF
+		style.Estimate = PROGRESS_BAR_ADDON_OFF
+		style.Percentage = PROGRESS_BAR_ADDON_OFF
+		style.Rate = PROGRESS_BAR_ADDON_APPEND
+		pb := NewBytesProgressBar(10 * 1024 * 1024)
+		pb.SetStyle(style).SetRenderWidth(50)
+		So(pb.Bytes(), ShouldBeTrue)
+
+		Convey("Renders sizes and rate", func() {
+			pb.Set64(5 * 1024 * 1024)
+			pb.started = time.Now().Add(-time.Second * 10)
+			pb.Estimator().(*ProgressBarWindowEstimator).samples = []progressBarSample{{time.Second * 8, 1024 * 1024}}
+			So(pb.Render(), ShouldEqual, " 5.0MB/10.0MB [============>-------------] 2.0MB/s")
+		})
+
+		Convey("Reader and writer advance the bar", func() {
+			reader := pb.Reader(strings.NewReader(strings.Repeat("x", 2048)))
+			buf := bytes.NewBuffer(nil)
+			n, err := io.Copy(pb.Writer(buf), reader)
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 2048)
+			So(pb.Position64(), ShouldEqual, 4096)
+		})
+
+		Convey("Streams longer than the size increase the size", func() {
+			pb.SetSize64(1024)
+			n, err := io.Copy(ioutil.Discard, pb.Reader(strings.NewReader(strings.Repeat("x", 2048))))
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 2048)
+			So(pb.Position64(), ShouldEqual, 2048)
+			So(pb.size, ShouldEqual, 2048)
+			So(pb.Done(), ShouldBeTrue)
+		})
+	})
+
+	Convey("Styles without byte and rate renderers use the defaults", t, func() {
+		pb := NewBytesProgressBar(2048)
+		pb.SetStyle(&ProgressBarStyle{
+			Empty:            '-',
+			Progress:         '=',
+			Rightmost:        '>',
+			None:             '-',
+			LeftBorder:       '[',
+			RightBorder:      ']',
+			Count:            PROGRESS_BAR_ADDON_PREPEND,
+			Rate:             PROGRESS_BAR_ADDON_APPEND,
+			RenderCount:      ProgressBarDefaultRenderCount,
+			RenderElapsed:    ProgressBarDefaultRenderElapsed,
+			RenderEstimate:   ProgressBarDefaultRenderEstimated,
+			RenderPercentage: ProgressBarDefaultRenderPercentage,
+			RenderPrefix:     ProgressBarDefaultRenderPrefix,
+			RenderSuffix:     ProgressBarDefaultRenderSuffix,
+		}).SetRenderWidth(30)
+		pb.Set64(1024)
+		So(pb.Render(), ShouldStartWith, "1.0KB/2.0KB [")
+		So(pb.Render(), ShouldEndWith, "/s")
+	})
+}
+
+func TestProgressBarPoolLines(t *testing.T) {
+	Convey("Progress bar pools print status lines, if output is not a terminal", t, func() {
+		pb := NewProgressBar(1000)
+		pb.Set(450)
+		pb.started = time.Now().Add(time.Second * -10)
+		So(ProgressBarPoolRenderLine("foo", pb), ShouldEqual, "foo: 45% (450/1000) ~00:12")
+		pb.SetLabel("Foo").SetMessage("uploading foo.tar")
+		So(ProgressBarPoolRenderLine("foo", pb), ShouldEqual, "Foo: 45% (450/1000) ~00:12 uploading foo.tar")
+		pb.Fail()
+		So(ProgressBarPoolRenderLine("foo", pb), ShouldEqual, "Foo: 45% (450/1000) failed uploading foo.tar")
+
+		Convey("Pool prints lines on finish", func() {
+			buf := bytes.NewBuffer(nil)
+			pool := NewProgressBarPool()
+			pool.Writer(buf)
+			pool.Interval(time.Hour)
+			pool.Init("foo", 10)
+			spinner, _ := pool.InitSpinner("bar")
+			spinner.SetMessage("waiting")
+			pool.Start()
+			<-pool.Finish()
+			So(buf.String(), ShouldEqual, "foo: 100% (10/10)\nbar: waiting done\n")
+		})
+
+		Convey("Quiet pool prints nothing", func() {
+			buf := bytes.NewBuffer(nil)
+			pool := NewProgressBarPool()
+			pool.Writer(buf)
+			pool.Mode(PROGRESS_BAR_POOL_QUIET)
+			pool.Init("foo", 10)
+			pool.Start()
+			<-pool.Finish()
+			So(buf.String(), ShouldEqual, "")
+		})
+	})
+}
+
+func TestProgressBarPoolDynamic(t *testing.T) {
+	Convey("Progress bar pools with changing bars", t, func() {
+		buf := bytes.NewBuffer(nil)
+		pool := NewProgressBarPool().(*progressBarPool)
+		pool.Writer(buf)
+		foo, _ := pool.Init("foo", 10)
+		bar, _ := pool.Init("bar", 10)
+		total := pool.Total()
+		So(pool.Total(), ShouldEqual, total)
+		So(pool.Increment("foo", "bar"), ShouldBeNil)
+
+		Convey("Remove bars", func() {
+			So(pool.Remove("foo"), ShouldBeNil)
+			So(pool.Has("foo"), ShouldBeFalse)
+			So(pool.Remove("foo"), ShouldNotBeNil)
+			pool.updateTotal()
+			So(total.Position64(), ShouldEqual, 2)
+			So(total.Size64(), ShouldEqual, 11)
+		})
+
+		Convey("Collapse completed bars into summary", func() {
+			pool.Collapse(0)
+			foo.Finish()
+			bar.Set(5)
+			pool.render(PROGRESS_BAR_POOL_LINES)
+			lines := strings.Split(buf.String(), "\n")
+			So(len(lines), ShouldEqual, 4)
+			So(lines[0], ShouldEqual, "1 completed")
+			So(lines[1], ShouldStartWith, "bar: 50% (5/10)")
+			So(lines[2], ShouldStartWith, "Total: 75% (15/20)")
+			So(pool.Has("foo"), ShouldBeFalse)
+
+			Convey("Failed bars are counted separately", func() {
+				bar.Fail()
+				pool.render(PROGRESS_BAR_POOL_LINES)
+				lines = strings.Split(buf.String(), "\n")
+				So(lines[3], ShouldEqual, "1 completed, 1 failed")
+				So(lines[4], ShouldStartWith, "Total: 100% (15/15)")
+			})
+		})
+
+		Convey("Collapse after delay", func() {
+			pool.Collapse(time.Hour)
+			foo.Finish()
+			pool.render(PROGRESS_BAR_POOL_LINES)
+			So(pool.Has("foo"), ShouldBeTrue)
+		})
+	})
+}
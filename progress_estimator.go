@@ -0,0 +1,198 @@
+package clif
+
+import (
+	"time"
+)
+
+type (
+
+	// ProgressBarEstimator calculates the rate of a progress bar, which is
+	// used for the estimate of the remaining time and the rate addon. Each bar
+	// requires it's own estimator.
+	ProgressBarEstimator interface {
+
+		// Rate returns the progress per second at the given duration since
+		// the start of the progress bar
+		Rate(elapsed time.Duration) float64
+
+		// Reset forgets all recorded positions
+		Reset()
+
+		// Update records the position of the progress bar at the given duration
+		// since the start of the progress bar
+		Update(position int64, elapsed time.Duration)
+	}
+
+	// ProgressBarLinearEstimator calculates the average rate since the start
+	// of the progress bar
+	ProgressBarLinearEstimator struct {
+		position int64
+	}
+
+	// ProgressBarEmaEstimator calculates the exponential moving average of
+	// the rate, which adapts to changing rates while smoothing bursts
+	ProgressBarEmaEstimator struct {
+
+		// Alpha is the smoothing factor between 0 and 1. Higher values weight
+		// recent rates more.
+		Alpha float64
+
+		// Interval is the minimal duration between two samples
+		Interval time.Duration
+
+		position int64
+		rate     float64
+		sampled  bool
+		last     progressBarSample
+	}
+
+	// ProgressBarWindowEstimator calculates the average rate over a window of
+	// recent time
+	ProgressBarWindowEstimator struct {
+
+		// Window is the time span over which the rate is averaged
+		Window time.Duration
+
+		position int64
+		samples  []progressBarSample
+	}
+
+	progressBarSample struct {
+		elapsed  time.Duration
+		position int64
+	}
+)
+
+var (
+	// ProgressBarRateWindow is the default time span over which window
+	// estimators average the rate
+	ProgressBarRateWindow = time.Second * 5
+
+	// ProgressBarEmaAlpha is the default smoothing factor of exponential moving
+	// average estimators
+	ProgressBarEmaAlpha = 0.1
+
+	// ProgressBarEmaInterval is the default minimal duration between two
+	// samples of exponential moving average estimators
+	ProgressBarEmaInterval = time.Millisecond * 250
+)
+
+// NewProgressBarLinearEstimator constructs an estimator, which extrapolates
+// linearly from the start of the progress bar. It's the default.
+func NewProgressBarLinearEstimator() *ProgressBarLinearEstimator {
+	return &ProgressBarLinearEstimator{}
+}
+
+// Rate returns the average progress per second since the start
+func (this *ProgressBarLinearEstimator) Rate(elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(this.position) / elapsed.Seconds()
+}
+
+// Reset forgets all recorded positions
+func (this *ProgressBarLinearEstimator) Reset() {
+	this.position = 0
+}
+
+// Update records the position
+func (this *ProgressBarLinearEstimator) Update(position int64, elapsed time.Duration) {
+	this.position = position
+}
+
+// NewProgressBarEmaEstimator constructs an exponential moving average estimator
+// with given smoothing factor. Uses `ProgressBarEmaAlpha`, if alpha is not
+// between 0 and 1.
+func NewProgressBarEmaEstimator(alpha float64) *ProgressBarEmaEstimator {
+	if alpha <= 0 || alpha > 1 {
+		alpha = ProgressBarEmaAlpha
+	}
+	return &ProgressBarEmaEstimator{
+		Alpha:    alpha,
+		Interval: ProgressBarEmaInterval,
+	}
+}
+
+// Rate returns the smoothed progress per second. If no progress has been
+// made since the last sample, the rate decreases.
+func (this *ProgressBarEmaEstimator) Rate(elapsed time.Duration) float64 {
+	if dt := elapsed - this.last.elapsed; dt > 0 && dt >= this.Interval {
+		return this.blend(float64(this.position-this.last.position) / dt.Seconds())
+	} else if !this.sampled && elapsed > 0 {
+		return float64(this.position) / elapsed.Seconds()
+	}
+	return this.rate
+}
+
+// Reset forgets all recorded positions
+func (this *ProgressBarEmaEstimator) Reset() {
+	this.position = 0
+	this.rate = 0
+	this.sampled = false
+	this.last = progressBarSample{}
+}
+
+// Update records the position. A new sample is taken, if the last is older
+// than the interval.
+func (this *ProgressBarEmaEstimator) Update(position int64, elapsed time.Duration) {
+	this.position = position
+	dt := elapsed - this.last.elapsed
+	if dt <= 0 || dt < this.Interval {
+		return
+	}
+	this.rate = this.blend(float64(position-this.last.position) / dt.Seconds())
+	this.sampled = true
+	this.last = progressBarSample{elapsed, position}
+}
+
+func (this *ProgressBarEmaEstimator) blend(rate float64) float64 {
+	if !this.sampled {
+		return rate
+	}
+	return this.Alpha*rate + (1-this.Alpha)*this.rate
+}
+
+// NewProgressBarWindowEstimator constructs an estimator, which averages the
+// rate over the given window. Uses `ProgressBarRateWindow`, if window is not
+// positive.
+func NewProgressBarWindowEstimator(window time.Duration) *ProgressBarWindowEstimator {
+	if window <= 0 {
+		window = ProgressBarRateWindow
+	}
+	estimator := &ProgressBarWindowEstimator{Window: window}
+	estimator.Reset()
+	return estimator
+}
+
+// Rate returns the progress per second within the window
+func (this *ProgressBarWindowEstimator) Rate(elapsed time.Duration) float64 {
+	base := this.samples[0]
+	if dt := elapsed - base.elapsed; dt > 0 {
+		return float64(this.position-base.position) / dt.Seconds()
+	}
+	return 0
+}
+
+// Reset forgets all recorded positions
+func (this *ProgressBarWindowEstimator) Reset() {
+	this.position = 0
+	this.samples = []progressBarSample{{0, 0}}
+}
+
+// Update records the position. Samples are taken at most every tenth of the
+// window and samples outside of the window are removed, except the last one,
+// which is the base of the average.
+func (this *ProgressBarWindowEstimator) Update(position int64, elapsed time.Duration) {
+	this.position = position
+	if last := this.samples[len(this.samples)-1]; elapsed-last.elapsed < this.Window/10 {
+		return
+	}
+	this.samples = append(this.samples, progressBarSample{elapsed, position})
+	cutoff := elapsed - this.Window
+	idx := 0
+	for idx < len(this.samples)-1 && this.samples[idx+1].elapsed < cutoff {
+		idx++
+	}
+	this.samples = this.samples[idx:]
+}
@@ -0,0 +1,70 @@
+package clif
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestProgressBarLinearEstimator(t *testing.T) {
+	Convey("Linear estimator averages since start", t, func() {
+		estimator := NewProgressBarLinearEstimator()
+		So(estimator.Rate(time.Second), ShouldEqual, 0)
+		estimator.Update(10, time.Second)
+		estimator.Update(100, time.Second*2)
+		So(estimator.Rate(time.Second*4), ShouldEqual, 25)
+		estimator.Reset()
+		So(estimator.Rate(time.Second*4), ShouldEqual, 0)
+	})
+}
+
+func TestProgressBarEmaEstimator(t *testing.T) {
+	Convey("EMA estimator smoothes rates", t, func() {
+		estimator := NewProgressBarEmaEstimator(0.5)
+		estimator.Update(10, time.Second)
+		So(estimator.Rate(time.Second), ShouldEqual, 10)
+		estimator.Update(40, time.Second*2)
+		So(estimator.Rate(time.Second*2), ShouldEqual, 20)
+
+		Convey("Samples within interval are accumulated", func() {
+			estimator.Update(45, time.Second*2+time.Millisecond*100)
+			So(estimator.Rate(time.Second*2+time.Millisecond*100), ShouldEqual, 20)
+		})
+
+		Convey("Rate decreases on stall", func() {
+			So(estimator.Rate(time.Second*4), ShouldEqual, 10)
+		})
+
+		Convey("Invalid alpha uses default", func() {
+			So(NewProgressBarEmaEstimator(2).Alpha, ShouldEqual, ProgressBarEmaAlpha)
+		})
+	})
+}
+
+func TestProgressBarWindowEstimator(t *testing.T) {
+	Convey("Window estimator averages over recent time", t, func() {
+		estimator := NewProgressBarWindowEstimator(time.Second * 10)
+		for i := 1; i <= 20; i++ {
+			position := int64(i * 10)
+			if i > 10 {
+				position = 100 + int64(i-10)*100
+			}
+			estimator.Update(position, time.Second*time.Duration(i))
+		}
+		So(estimator.Rate(time.Second*20), ShouldAlmostEqual, 1010.0/11)
+		So(len(estimator.samples), ShouldBeLessThanOrEqualTo, 12)
+	})
+}
+
+func TestProgressBarEstimate(t *testing.T) {
+	Convey("Progress bars estimate with their estimator", t, func() {
+		pb := NewProgressBar(100)
+		pb.SetEstimator(NewProgressBarWindowEstimator(time.Second * 10))
+		pb.Set(10)
+		pb.started = time.Now().Add(-time.Second * 20)
+		pb.Estimator().Update(50, time.Second*10)
+		pb.Set(60)
+		So(pb.Rate(), ShouldBeBetween, 0.9, 1.1)
+		So(pb.Estimate(), ShouldBeBetween, time.Second*36, time.Second*45)
+	})
+}
@@ -0,0 +1,289 @@
+package clif
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// ProgressSpinner shows progress of unknown size, eg waiting on a lock
+	ProgressSpinner interface {
+
+		// Done returns whether the spinner is finished, either successful or failed
+		Done() bool
+
+		// Fail stops the spinner and marks it as failed
+		Fail()
+
+		// Failed returns whether the spinner is failed
+		Failed() bool
+
+		// Finish stops the spinner and marks it as successful
+		Finish()
+
+		// Message returns the message rendered next to the spinner
+		Message() string
+
+		// Render returns the rendered spinner in it's current state
+		Render() string
+
+		// RenderWidth returns the width of the rendered output (amount of chars (rune))
+		RenderWidth() int
+
+		// Reset restarts the spinner and unsets all timers
+		Reset()
+
+		// SetMessage sets the message rendered next to the spinner
+		SetMessage(message string) ProgressSpinner
+
+		// SetRenderWidth sets total width of rendered output to given amount of characters (runes)
+		SetRenderWidth(v int) ProgressSpinner
+
+		// SetStyle sets the style of the spinner
+		SetStyle(style *ProgressSpinnerStyle) ProgressSpinner
+
+		// Style returns the style of the spinner
+		Style() *ProgressSpinnerStyle
+	}
+
+	// ProgressSpinnerStyle defines how a spinner is rendered
+	ProgressSpinnerStyle struct {
+
+		// Frames are rendered in turn while the spinner is running
+		Frames []string
+
+		// Interval is the duration each frame is shown
+		Interval time.Duration
+
+		// Success replaces the frames when the spinner is finished
+		Success string
+
+		// Failure replaces the frames when the spinner is failed
+		Failure string
+
+		// Elapsed is the position of the elapsed time. Prepend is rendered
+		// between frame and message, append at the end of the line.
+		Elapsed progressBarAddon
+
+		// RenderElapsed renders the elapsed time
+		RenderElapsed func(elapsed time.Duration, spinner ProgressSpinner) string
+	}
+
+	// ProgressSpinnerSimple is a spinner for progress of unknown size
+	ProgressSpinnerSimple struct {
+
+		// failed is true if the spinner has been failed
+		failed bool
+
+		// finished is true if the spinner has been finished or failed
+		finished bool
+
+		// message is rendered next to the spinner
+		message string
+
+		// renderWidth is the max size of characters
+		renderWidth int
+
+		// style is the rendering style of the spinner
+		style *ProgressSpinnerStyle
+
+		// started holds the time of the first rendering
+		started time.Time
+
+		// stopped holds the time when the spinner was finished or failed
+		stopped time.Time
+
+		mux *sync.Mutex
+	}
+)
+
+var (
+	// ProgressSpinnerDefaultRenderElapsed renders the elapsed time like progress bars do
+	ProgressSpinnerDefaultRenderElapsed = func(elapsed time.Duration, spinner ProgressSpinner) string {
+		return "@" + RenderFixedSizeDuration(elapsed)
+	}
+
+	// ProgressSpinnerStyleAscii is an ASCII encoding based style for rendering spinners
+	ProgressSpinnerStyleAscii = &ProgressSpinnerStyle{
+		Frames:   []string{"|", "/", "-", "\\"},
+		Interval: time.Millisecond * 100,
+		Success:  "+",
+		Failure:  "x",
+		Elapsed:  PROGRESS_BAR_ADDON_APPEND,
+	}
+
+	// ProgressSpinnerStyleUtf8 is an UTF-8 encoding based style for rendering spinners
+	ProgressSpinnerStyleUtf8 = &ProgressSpinnerStyle{
+		Frames:   []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+		Interval: time.Millisecond * 80,
+		Success:  "✔",
+		Failure:  "✘",
+		Elapsed:  PROGRESS_BAR_ADDON_APPEND,
+	}
+)
+
+// NewProgressSpinner constructs a new spinner with UTF-8 style
+func NewProgressSpinner() *ProgressSpinnerSimple {
+	return &ProgressSpinnerSimple{
+		renderWidth: termWidthCurrent(),
+		mux:         new(sync.Mutex),
+		style:       ProgressSpinnerStyleUtf8,
+	}
+}
+
+// CloneProgressSpinnerStyle returns a copy of the given spinner style
+func CloneProgressSpinnerStyle(from *ProgressSpinnerStyle) *ProgressSpinnerStyle {
+	to := *from
+	to.Frames = append([]string{}, from.Frames...)
+	return &to
+}
+
+// Done returns whether the spinner is finished, either successful or failed
+func (this *ProgressSpinnerSimple) Done() bool {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return this.finished
+}
+
+// Fail stops the spinner and marks it as failed
+func (this *ProgressSpinnerSimple) Fail() {
+	this.stop(true)
+}
+
+// Failed returns whether the spinner is failed
+func (this *ProgressSpinnerSimple) Failed() bool {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return this.failed
+}
+
+// Finish stops the spinner and marks it as successful
+func (this *ProgressSpinnerSimple) Finish() {
+	this.stop(false)
+}
+
+// Message returns the message rendered next to the spinner
+func (this *ProgressSpinnerSimple) Message() string {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return this.message
+}
+
+// Render returns the rendered spinner in it's current state
+func (this *ProgressSpinnerSimple) Render() string {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.started.IsZero() {
+		this.started = time.Now()
+	}
+
+	var elapsed time.Duration
+	frame := ""
+	if this.finished {
+		elapsed = this.stopped.Sub(this.started)
+		if this.failed {
+			frame = this.style.Failure
+		} else {
+			frame = this.style.Success
+		}
+	} else {
+		elapsed = time.Now().Sub(this.started)
+		if l := len(this.style.Frames); l > 0 {
+			idx := 0
+			if this.style.Interval > 0 {
+				idx = int(elapsed/this.style.Interval) % l
+			}
+			frame = this.style.Frames[idx]
+		}
+	}
+
+	prefix := frame + " "
+	suffix := ""
+	if this.style.Elapsed != PROGRESS_BAR_ADDON_OFF && this.style.RenderElapsed != nil {
+		if this.style.Elapsed == PROGRESS_BAR_ADDON_PREPEND {
+			prefix += this.style.RenderElapsed(elapsed, this) + " "
+		} else {
+			suffix = " " + this.style.RenderElapsed(elapsed, this)
+		}
+	}
+
+	width := this.renderWidth - StringLength(prefix) - StringLength(suffix)
+	if width < 0 {
+		width = 0
+	}
+	message := TruncateString(this.message, width, "…")
+	if suffix != "" {
+		message += strings.Repeat(" ", width-StringLength(message))
+	}
+	return prefix + message + suffix
+}
+
+// RenderWidth returns the width of the rendered output (amount of chars (rune))
+func (this *ProgressSpinnerSimple) RenderWidth() int {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return this.renderWidth
+}
+
+// Reset restarts the spinner and unsets all timers
+func (this *ProgressSpinnerSimple) Reset() {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.failed = false
+	this.finished = false
+	this.started = time.Time{}
+	this.stopped = time.Time{}
+}
+
+// SetMessage sets the message rendered next to the spinner
+func (this *ProgressSpinnerSimple) SetMessage(message string) ProgressSpinner {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.message = message
+	return this
+}
+
+// SetRenderWidth is builder method to set render width
+func (this *ProgressSpinnerSimple) SetRenderWidth(v int) ProgressSpinner {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.renderWidth = v
+	return this
+}
+
+// SetStyle sets the rendering style (i.e. the frames) of the spinner
+func (this *ProgressSpinnerSimple) SetStyle(style *ProgressSpinnerStyle) ProgressSpinner {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.style = style
+	return this
+}
+
+// Style returns the currently used style
+func (this *ProgressSpinnerSimple) Style() *ProgressSpinnerStyle {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return this.style
+}
+
+func (this *ProgressSpinnerSimple) stop(failed bool) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.finished {
+		return
+	}
+	now := time.Now()
+	if this.started.IsZero() {
+		this.started = now
+	}
+	this.finished = true
+	this.failed = failed
+	this.stopped = now
+}
+
+func init() {
+	for _, s := range []*ProgressSpinnerStyle{ProgressSpinnerStyleAscii, ProgressSpinnerStyleUtf8} {
+		s.RenderElapsed = ProgressSpinnerDefaultRenderElapsed
+	}
+}
@@ -0,0 +1,79 @@
+package clif
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+func TestProgressSpinnerRender(t *testing.T) {
+	Convey("Rendering spinners", t, func() {
+		style := CloneProgressSpinnerStyle(ProgressSpinnerStyleAscii)
+		spinner := NewProgressSpinner().SetStyle(style).SetRenderWidth(30).(*ProgressSpinnerSimple)
+		spinner.SetMessage("Waiting for lock")
+		spinner.started = time.Now().Add(time.Minute*-2 - time.Millisecond*150)
+
+		Convey("Frames change with interval", func() {
+			So(spinner.Render(), ShouldEqual, "/ Waiting for lock     @02m00s")
+			spinner.started = spinner.started.Add(time.Millisecond * -100)
+			So(spinner.Render(), ShouldEqual, "- Waiting for lock     @02m00s")
+		})
+
+		Convey("Elapsed time can be prepended or disabled", func() {
+			style.Elapsed = PROGRESS_BAR_ADDON_PREPEND
+			So(spinner.Render(), ShouldEqual, "/ @02m00s Waiting for lock")
+			style.Elapsed = PROGRESS_BAR_ADDON_OFF
+			So(spinner.Render(), ShouldEqual, "/ Waiting for lock")
+		})
+
+		Convey("Long messages are truncated", func() {
+			spinner.SetMessage("Waiting for the lock of the database")
+			So(spinner.Render(), ShouldEqual, "/ Waiting for the loc… @02m00s")
+			So(StringLength(spinner.Render()), ShouldEqual, 30)
+		})
+
+		Convey("Finished spinners show success", func() {
+			spinner.Finish()
+			So(spinner.Done(), ShouldBeTrue)
+			So(spinner.Failed(), ShouldBeFalse)
+			So(spinner.Render(), ShouldStartWith, "+ Waiting for lock")
+
+			Convey("Finishing again does not change state", func() {
+				spinner.Fail()
+				So(spinner.Failed(), ShouldBeFalse)
+			})
+		})
+
+		Convey("Failed spinners show failure", func() {
+			spinner.Fail()
+			So(spinner.Done(), ShouldBeTrue)
+			So(spinner.Failed(), ShouldBeTrue)
+			So(spinner.Render(), ShouldStartWith, "x Waiting for lock")
+
+			Convey("Reset restarts", func() {
+				spinner.Reset()
+				So(spinner.Done(), ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestProgressBarPoolSpinners(t *testing.T) {
+	Convey("Spinners are managed by the pool", t, func() {
+		pool := NewProgressBarPool().(*progressBarPool)
+		pool.Width(40)
+		pool.Init("bar", 10)
+		spinner, err := pool.InitSpinner("spinner")
+		So(err, ShouldBeNil)
+		So(pool.Has("spinner"), ShouldBeTrue)
+		So(spinner.RenderWidth(), ShouldEqual, 40)
+
+		_, err = pool.InitSpinner("bar")
+		So(err, ShouldNotBeNil)
+		_, err = pool.Init("spinner", 10)
+		So(err, ShouldNotBeNil)
+
+		So(pool.SpinnerStyle(ProgressSpinnerStyleAscii), ShouldBeNil)
+		So(spinner.Style(), ShouldEqual, ProgressSpinnerStyleAscii)
+	})
+}
@@ -23,11 +23,14 @@ type (
 		Elapsed          progressBarAddon
 		Estimate         progressBarAddon
 		Percentage       progressBarAddon
+		Rate             progressBarAddon
+		RenderBytes      func(pos, max int64, bar ProgressBar) string
 		RenderCount      func(pos, max int, bar ProgressBar) string
 		RenderElapsed    func(elapsed time.Duration, bar ProgressBar) string
 		RenderEstimate   func(forecast time.Duration, bar ProgressBar) string
 		RenderPercentage func(percent float32, bar ProgressBar) string
 		RenderPrefix     func(count, elapsed, estimate, percentage string) string
+		RenderRate       func(rate float64, bar ProgressBar) string
 		RenderSuffix     func(count, elapsed, estimate, percentage string) string
 	}
 )
@@ -44,6 +47,10 @@ var (
 		l := len(fmt.Sprintf("%d", max))
 		return fmt.Sprintf("%"+fmt.Sprintf("%d", l)+"d/%d", pos, max)
 	}
+	ProgressBarDefaultRenderBytes = func(pos, max int64, bar ProgressBar) string {
+		l := len(RenderByteSize(max))
+		return fmt.Sprintf("%"+fmt.Sprintf("%d", l)+"s/%s", RenderByteSize(pos), RenderByteSize(max))
+	}
 	ProgressBarDefaultRenderElapsed = func(elapsed time.Duration, bar ProgressBar) string {
 		return fmt.Sprintf("@%s", RenderFixedSizeDuration(elapsed))
 	}
@@ -69,6 +76,12 @@ var (
 		}
 		return strings.Join(out, " / ") + " "
 	}
+	ProgressBarDefaultRenderRate = func(rate float64, bar ProgressBar) string {
+		if bar.Bytes() {
+			return RenderByteSize(int64(rate)) + "/s"
+		}
+		return fmt.Sprintf("%.1f/s", rate)
+	}
 	ProgressBarDefaultRenderSuffix = func(count, elapsed, estimate, percentage string) string {
 		out := []string{}
 		for _, s := range []string{count, elapsed, estimate, percentage} {
@@ -114,11 +127,13 @@ func CloneProgressBarStyle(from *ProgressBarStyle) *ProgressBarStyle {
 
 func init() {
 	for _, s := range []*ProgressBarStyle{ProgressBarStyleAscii, ProgressBarStyleUtf8} {
+		s.RenderBytes = ProgressBarDefaultRenderBytes
 		s.RenderCount = ProgressBarDefaultRenderCount
 		s.RenderElapsed = ProgressBarDefaultRenderElapsed
 		s.RenderEstimate = ProgressBarDefaultRenderEstimated
 		s.RenderPercentage = ProgressBarDefaultRenderPercentage
 		s.RenderPrefix = ProgressBarDefaultRenderPrefix
+		s.RenderRate = ProgressBarDefaultRenderRate
 		s.RenderSuffix = ProgressBarDefaultRenderSuffix
 	}
 
@@ -1,8 +1,10 @@
 package clif
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 	"sync"
 )
 
@@ -12,40 +14,67 @@ type ReduceMethod func(name string, value interface{}) bool
 // Registry is a small container holding objects which are injected into command calls.
 type Registry struct {
 	Container map[string]reflect.Value
+
+	// Providers are constructors of objects, by the type name of the constructed
+	// object. They are called on first use.
+	Providers map[string]reflect.Value
 }
 
+var (
+	// ErrNotRegistered is returned by `Registry.Resolve()`, if neither an
+	// object nor a provider is registered for a type
+	ErrNotRegistered = fmt.Errorf("Not found in registry")
+
+	registryErrorType = reflect.TypeOf((*error)(nil)).Elem()
+)
+
 // NewRegistry constructs new, empty registry
 func NewRegistry() *Registry {
 	return &Registry{
 		Container: make(map[string]reflect.Value),
+		Providers: make(map[string]reflect.Value),
 	}
 }
 
-// Alias registers an object under a different name. Eg for interfaces.
+// Alias registers an object under a different name. Eg to choose between
+// multiple implementations of an interface.
 func (this *Registry) Alias(alias string, v interface{}) {
 	r := reflect.ValueOf(v)
 	this.Container[alias] = r
 }
 
-// Get returns registered object by their type name (`reflect.TypeOf(..).String()`)
+// Get returns registered object by their type name (`reflect.TypeOf(..).String()`).
+// If a provider is registered for the type, it is called on first use. Returns
+// an invalid value if not found or if the provider fails (see `GetE()`).
 func (this *Registry) Get(s string) reflect.Value {
+	v, _ := this.GetE(s)
+	return v
+}
+
+// GetE returns registered object by their type name, like `Get()`. Returns
+// `ErrNotRegistered`, if not found, or the error of a failing provider.
+func (this *Registry) GetE(s string) (reflect.Value, error) {
 	if v, ok := this.Container[s]; ok {
-		return v
-	} else {
-		return reflect.ValueOf(nil)
+		return v, nil
+	} else if _, ok := this.Providers[s]; ok {
+		return this.provide(s, nil)
 	}
+	return reflect.ValueOf(nil), ErrNotRegistered
 }
 
-// Has checks whether a requested type is registered
+// Has checks whether a requested type or a provider of it is registered
 func (this *Registry) Has(s string) bool {
 	if _, ok := this.Container[s]; ok {
 		return true
+	} else if _, ok := this.Providers[s]; ok {
+		return true
 	} else {
 		return false
 	}
 }
 
-// Names returns sorted (asc) list of registered names
+// Names returns sorted (asc) list of registered names. Providers which have
+// not been called yet are not included.
 func (this *Registry) Names() []string {
 	names := make([]string, len(this.Container))
 	i := 0
@@ -97,4 +126,117 @@ func (this *Registry) Register(v interface{}) {
 	this.Container[r.Type().String()] = r
 }
 
+// RegisterProvider adds a constructor of an object to the registry, eg
+// `func(cfg *Config) (*DB, error)`. The constructor is called on first use of
+// the type it returns and the result is registered as object. Parameters of the
+// constructor are resolved from the registry, like callback parameters. An
+// existing object of the same type would be replaced.
+func (this *Registry) RegisterProvider(provider interface{}) error {
+	ref := reflect.ValueOf(provider)
+	if ref.Kind() != reflect.Func {
+		return fmt.Errorf("Provider must be function, but is %s", ref.Kind())
+	}
+	t := ref.Type()
+	if t.IsVariadic() {
+		return fmt.Errorf("Provider %s must not be variadic", t)
+	} else if t.NumOut() < 1 || t.NumOut() > 2 || (t.NumOut() == 2 && t.Out(1) != registryErrorType) {
+		return fmt.Errorf("Provider %s must return an object and optionally an error", t)
+	}
+	name := t.Out(0).String()
+	delete(this.Container, name)
+	this.Providers[name] = ref
+	return nil
+}
+
+// Resolve returns the object for the given type. Objects registered under the
+// name of the type are preferred, then providers of the type. Interfaces are
+// resolved by any registered object or provider, which is assignable to the
+// interface, if it is the only one. Returns `ErrNotRegistered`, if nothing is
+// found, or an error if the type is ambiguous, a provider fails or providers
+// depend on each other.
+func (this *Registry) Resolve(t reflect.Type) (reflect.Value, error) {
+	return this.resolve(t, nil)
+}
 
+// resolve returns the object for the given type, see `Resolve()`. Resolving
+// are the names of the providers, which are currently called.
+func (this *Registry) resolve(t reflect.Type, resolving []string) (reflect.Value, error) {
+	name := t.String()
+	if v, ok := this.Container[name]; ok {
+		return v, nil
+	} else if _, ok := this.Providers[name]; ok {
+		return this.provide(name, resolving)
+	} else if t.Kind() != reflect.Interface {
+		return reflect.ValueOf(nil), ErrNotRegistered
+	}
+
+	// find all objects and providers, which implement the interface
+	candidates := make([]string, 0)
+	values := make([]reflect.Value, 0)
+	for _, n := range this.Names() {
+		v := this.Container[n]
+		if strings.Index(n, "N:") == 0 || !v.IsValid() || !v.Type().AssignableTo(t) {
+			continue
+		}
+		// the same pointer may be registered under multiple names
+		duplicate := false
+		for _, other := range values {
+			if v.Kind() == reflect.Ptr && other.Kind() == reflect.Ptr && v.Pointer() == other.Pointer() {
+				duplicate = true
+			}
+		}
+		if !duplicate {
+			candidates = append(candidates, n)
+			values = append(values, v)
+		}
+	}
+	providers := make([]string, 0)
+	for n, provider := range this.Providers {
+		if _, ok := this.Container[n]; !ok && provider.Type().Out(0).AssignableTo(t) {
+			providers = append(providers, n)
+		}
+	}
+	sort.Strings(providers)
+	candidates = append(candidates, providers...)
+
+	if len(candidates) == 0 {
+		return reflect.ValueOf(nil), ErrNotRegistered
+	} else if len(candidates) > 1 {
+		return reflect.ValueOf(nil), fmt.Errorf("Type %s is ambiguous, implemented by %s", name, strings.Join(candidates, ", "))
+	} else if len(values) == 1 {
+		return values[0], nil
+	}
+	return this.provide(candidates[0], resolving)
+}
+
+// provide calls the provider of the named type, after resolving its parameters,
+// and registers the result. Resolving are the names of the providers, which are
+// currently called, to detect cyclic dependencies.
+func (this *Registry) provide(name string, resolving []string) (reflect.Value, error) {
+	for idx, n := range resolving {
+		if n == name {
+			cycle := append(append([]string{}, resolving[idx:]...), name)
+			return reflect.ValueOf(nil), fmt.Errorf("Cyclic dependency: %s", strings.Join(cycle, " -> "))
+		}
+	}
+	resolving = append(append([]string{}, resolving...), name)
+
+	provider := this.Providers[name]
+	t := provider.Type()
+	input := make([]reflect.Value, t.NumIn())
+	for i := 0; i < t.NumIn(); i++ {
+		v, err := this.resolve(t.In(i), resolving)
+		if err == ErrNotRegistered {
+			return reflect.ValueOf(nil), fmt.Errorf("Provider of %s requires %s, which was not found in registry", name, t.In(i))
+		} else if err != nil {
+			return reflect.ValueOf(nil), err
+		}
+		input[i] = v
+	}
+	res := provider.Call(input)
+	if len(res) == 2 && !res[1].IsNil() {
+		return reflect.ValueOf(nil), fmt.Errorf("Provider of %s failed: %s", name, res[1].Interface().(error))
+	}
+	this.Container[name] = res[0]
+	return res[0], nil
+}
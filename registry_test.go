@@ -111,4 +111,150 @@ func TestRegistryReduce(t *testing.T) {
 		})
 
 	})
-}
\ No newline at end of file
+}
+type testRegistryConfig struct {
+	Dsn string
+}
+
+type testRegistryDb struct {
+	Config *testRegistryConfig
+}
+
+func (this *testRegistryDb) Bar() string {
+	return this.Config.Dsn
+}
+
+type testRegistryValue struct {
+	Data interface{}
+}
+
+func (this testRegistryValue) Bar() string {
+	return "value"
+}
+
+type testRegistryCycleA struct{}
+type testRegistryCycleB struct{}
+
+func TestRegistryInterfaceAssignability(t *testing.T) {
+	Convey("With new registry", t, func() {
+		reg := NewRegistry()
+		foo := reflect.TypeOf((*testFoo)(nil)).Elem()
+
+		Convey("Interface is resolved by implementing object", func() {
+			v := new(testBar)
+			reg.Register(v)
+			res, err := reg.Resolve(foo)
+			So(err, ShouldBeNil)
+			So(res.Interface(), ShouldEqual, v)
+		})
+		Convey("Object registered under interface name is preferred", func() {
+			v := new(testBar)
+			reg.Register(new(testBar))
+			reg.Register(&testRegistryDb{})
+			reg.Alias("clif.testFoo", v)
+			res, err := reg.Resolve(foo)
+			So(err, ShouldBeNil)
+			So(res.Interface(), ShouldEqual, v)
+		})
+		Convey("Same object under multiple names is not ambiguous", func() {
+			v := new(testBar)
+			reg.Register(v)
+			reg.Alias("other", v)
+			res, err := reg.Resolve(foo)
+			So(err, ShouldBeNil)
+			So(res.Interface(), ShouldEqual, v)
+		})
+		Convey("Values with uncomparable contents are compared safely", func() {
+			reg.Alias("one", testRegistryValue{map[string]int{}})
+			reg.Alias("two", testRegistryValue{map[string]int{}})
+			_, err := reg.Resolve(foo)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "Type clif.testFoo is ambiguous, implemented by one, two")
+		})
+		Convey("Multiple implementing objects are ambiguous", func() {
+			reg.Register(new(testBar))
+			reg.Register(&testRegistryDb{})
+			_, err := reg.Resolve(foo)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "Type clif.testFoo is ambiguous, implemented by *clif.testBar, *clif.testRegistryDb")
+		})
+		Convey("Named parameters are not considered", func() {
+			reg.Alias("N:foo", new(testBar))
+			_, err := reg.Resolve(foo)
+			So(err, ShouldEqual, ErrNotRegistered)
+		})
+		Convey("Structs are not resolved by assignability", func() {
+			reg.Alias("foo", new(testBar))
+			_, err := reg.Resolve(reflect.TypeOf(new(testBar)))
+			So(err, ShouldEqual, ErrNotRegistered)
+		})
+	})
+}
+
+func TestRegistryProviders(t *testing.T) {
+	Convey("With new registry", t, func() {
+		reg := NewRegistry()
+		calls := 0
+		err := reg.RegisterProvider(func(cfg *testRegistryConfig) (*testRegistryDb, error) {
+			calls++
+			if cfg.Dsn == "" {
+				return nil, fmt.Errorf("Missing dsn")
+			}
+			return &testRegistryDb{cfg}, nil
+		})
+		So(err, ShouldBeNil)
+		So(reg.Has("*clif.testRegistryDb"), ShouldBeTrue)
+		So(reg.Names(), ShouldResemble, []string{})
+
+		Convey("Provider is called once with resolved dependencies", func() {
+			cfg := &testRegistryConfig{"foo://bar"}
+			reg.Register(cfg)
+			res, err := reg.Resolve(reflect.TypeOf(new(testRegistryDb)))
+			So(err, ShouldBeNil)
+			So(res.Interface().(*testRegistryDb).Config, ShouldEqual, cfg)
+			res2, err := reg.Resolve(reflect.TypeOf(new(testRegistryDb)))
+			So(err, ShouldBeNil)
+			So(res2.Interface(), ShouldEqual, res.Interface())
+			So(calls, ShouldEqual, 1)
+			So(reg.Get("*clif.testRegistryDb").Interface(), ShouldEqual, res.Interface())
+		})
+		Convey("Dependencies can be provided", func() {
+			reg.RegisterProvider(func() *testRegistryConfig {
+				return &testRegistryConfig{"from://provider"}
+			})
+			res, err := reg.Resolve(reflect.TypeOf((*testFoo)(nil)).Elem())
+			So(err, ShouldBeNil)
+			So(res.Interface().(testFoo).Bar(), ShouldEqual, "from://provider")
+		})
+		Convey("Missing dependency fails", func() {
+			_, err := reg.Resolve(reflect.TypeOf(new(testRegistryDb)))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "Provider of *clif.testRegistryDb requires *clif.testRegistryConfig, which was not found in registry")
+			So(reg.Get("*clif.testRegistryDb").IsValid(), ShouldBeFalse)
+		})
+		Convey("Provider error is returned", func() {
+			reg.Register(&testRegistryConfig{})
+			_, err := reg.Resolve(reflect.TypeOf(new(testRegistryDb)))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "Provider of *clif.testRegistryDb failed: Missing dsn")
+			So(reg.Get("*clif.testRegistryDb").IsValid(), ShouldBeFalse)
+			_, err = reg.GetE("*clif.testRegistryDb")
+			So(err.Error(), ShouldEqual, "Provider of *clif.testRegistryDb failed: Missing dsn")
+			_, err = reg.GetE("*clif.notThere")
+			So(err, ShouldEqual, ErrNotRegistered)
+		})
+		Convey("Cyclic dependencies fail", func() {
+			reg.RegisterProvider(func(b *testRegistryCycleB) *testRegistryCycleA { return nil })
+			reg.RegisterProvider(func(a *testRegistryCycleA) *testRegistryCycleB { return nil })
+			_, err := reg.Resolve(reflect.TypeOf(new(testRegistryCycleA)))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "Cyclic dependency: *clif.testRegistryCycleA -> *clif.testRegistryCycleB -> *clif.testRegistryCycleA")
+		})
+		Convey("Invalid providers are rejected", func() {
+			So(reg.RegisterProvider("foo"), ShouldNotBeNil)
+			So(reg.RegisterProvider(func() {}), ShouldNotBeNil)
+			So(reg.RegisterProvider(func() (int, int) { return 1, 2 }), ShouldNotBeNil)
+			So(reg.RegisterProvider(func(x ...int) int { return 1 }), ShouldNotBeNil)
+		})
+	})
+}
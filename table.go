@@ -2,6 +2,7 @@ package clif
 
 import (
 	"fmt"
+	"strings"
 )
 
 type (
@@ -15,6 +16,12 @@ type (
 		// set of output headers
 		Headers *TableRow
 
+		// Footer is an optional row, which is rendered below all rows
+		Footer *TableRow
+
+		// columns contain the rendering settings per column
+		columns []*TableColumnSettings
+
 		// colAmount is the amount of cols per row (fixed size)
 		colAmount int
 
@@ -24,6 +31,19 @@ type (
 		// set of row, col, lines
 		Rows []*TableRow
 
+		// separatorPending is true if the next added row starts a new group
+		separatorPending bool
+
+		// rowStyles are applied to the data rows in turn. See `SetRowStyles()`
+		rowStyles []string
+
+		// cellStyle returns the styled content of a data cell. See `SetCellStyle()`
+		cellStyle func(row, col int, value string) string
+
+		// origins are the indices of the columns in the original table, if the
+		// table contains only some of its columns
+		origins []int
+
 		// Style for rendering table
 		style *TableStyle
 	}
@@ -48,6 +68,15 @@ type (
 		Suffix          string
 		HeaderRenderer  func(content string) string
 		ContentRenderer func(content string) string
+		FooterRenderer  func(content string) string
+
+		// Separator* are used for the line between groups of rows (see
+		// `Table.AddSeparator()`) and above the footer. If empty, the inner
+		// lines are used.
+		SeparatorHorizontal string
+		SeparatorCross      string
+		SeparatorLeft       string
+		SeparatorRight      string
 	}
 
 	TableRow struct {
@@ -55,7 +84,15 @@ type (
 		MaxLineCount int
 		ColAmount    int
 		Cols         []*TableCol
-		table        *Table
+
+		// Separator is true if the row starts a new group of rows, which is
+		// separated from the rows above. See `Table.AddSeparator()`
+		Separator bool
+
+		// spans contains the amount of cols each col spans. See `SetColSpan()`
+		spans []int
+
+		table *Table
 	}
 
 	TableCol struct {
@@ -109,10 +146,59 @@ func (this *Table) SetHeaders(headers []string) error {
 		return fmt.Errorf("Cannot set headers after data has been added")
 	}
 	this.colAmount = len(headers)
-	this.Headers = NewTableRow(headers)
+	this.Headers = NewTableRow(headers).SetTable(this)
+	this.columns = make([]*TableColumnSettings, this.colAmount)
+	for idx, _ := range this.columns {
+		this.columns[idx] = NewTableColumnSettings()
+	}
+	return nil
+}
+
+// Column returns the rendering settings (alignment, width, overflow) of the
+// column with the given index or nil, if the index is out of bounds.
+// Headers must be set beforehand.
+func (this *Table) Column(idx int) *TableColumnSettings {
+	if idx < 0 || idx >= len(this.columns) {
+		return nil
+	}
+	return this.columns[idx]
+}
+
+// SetFooter sets the footer of the table, eg a row with totals. Headers must
+// be set beforehand.
+func (this *Table) SetFooter(cols []string) error {
+	if err := this.checkAddCols(cols); err != nil {
+		return err
+	}
+	this.Footer = NewTableRow(cols).SetTable(this)
 	return nil
 }
 
+// AddSeparator starts a new group of rows: the next added row is separated
+// from the rows above
+func (this *Table) AddSeparator() *Table {
+	this.separatorPending = true
+	return this
+}
+
+// SetRowStyles is builder method and sets styles, which are applied to the
+// data rows in turn. Styles are style tokens (eg "<info>") or control
+// characters. Two styles, of which one can be empty, stripe the rows (zebra).
+func (this *Table) SetRowStyles(styles ...string) *Table {
+	this.rowStyles = styles
+	return this
+}
+
+// SetCellStyle is builder method and sets a callback, which returns the
+// styled content of a data cell, eg with style tokens added. The callback
+// receives the index of the row, as rendered, and the index of the column.
+// Row and column styles (see `SetRowStyles()` and
+// `TableColumnSettings.SetStyle()`) are applied additionally.
+func (this *Table) SetCellStyle(style func(row, col int, value string) string) *Table {
+	this.cellStyle = style
+	return this
+}
+
 // SetStyle changes the table style
 func (this *Table) SetStyle(style *TableStyle) {
 	this.style = style
@@ -192,10 +278,108 @@ func (this *Table) SetColumn(rowIdx, colIdx int, content string) error {
 
 func (this *Table) addRow(cols []string) {
 	row := NewTableRow(cols).SetTable(this)
+	row.Separator = this.separatorPending
+	this.separatorPending = false
 	this.Rows = append(this.Rows, row)
 	this.rowAmount++
 }
 
+// projectColumns returns a table, which contains only the columns with the
+// given indices. Rows and columns are shared with the original table.
+func (this *Table) projectColumns(indices []int) *Table {
+	table := &Table{
+		AllowEmptyFill: this.AllowEmptyFill,
+		colAmount:      len(indices),
+		rowAmount:      this.rowAmount,
+		Rows:           make([]*TableRow, len(this.Rows)),
+		columns:        make([]*TableColumnSettings, len(indices)),
+		rowStyles:      this.rowStyles,
+		cellStyle:      this.cellStyle,
+		origins:        make([]int, len(indices)),
+		style:          this.style,
+	}
+	project := func(row *TableRow) *TableRow {
+		projected := &TableRow{
+			MaxLineCount: row.MaxLineCount,
+			ColAmount:    len(indices),
+			Cols:         make([]*TableCol, len(indices)),
+			Separator:    row.Separator,
+			spans:        row.projectSpans(indices),
+			table:        table,
+		}
+		for idx, colIdx := range indices {
+			projected.Cols[idx] = row.Cols[colIdx]
+		}
+		return projected
+	}
+	for idx, colIdx := range indices {
+		table.columns[idx] = this.Column(colIdx)
+		table.origins[idx] = this.origin(colIdx)
+	}
+	table.Headers = project(this.Headers)
+	if this.Footer != nil {
+		table.Footer = project(this.Footer)
+	}
+	for idx, row := range this.Rows {
+		table.Rows[idx] = project(row)
+	}
+	return table
+}
+
+// origin returns the index of the column in the original table
+func (this *Table) origin(idx int) int {
+	if this.origins == nil {
+		return idx
+	}
+	return this.origins[idx]
+}
+
+// hasHiddenOverflow returns whether any column is hidden if it does not fit
+func (this *Table) hasHiddenOverflow() bool {
+	for _, settings := range this.columns {
+		if settings != nil && settings.Overflow == TABLE_OVERFLOW_HIDE {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCellStyles returns whether any row, column or cell styles are set
+func (this *Table) hasCellStyles() bool {
+	if len(this.rowStyles) > 0 || this.cellStyle != nil {
+		return true
+	}
+	for _, settings := range this.columns {
+		if settings != nil && settings.Style != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// styleContent applies the row, column and cell styles to the content of the
+// data cell with the given indices
+func (this *Table) styleContent(rowIdx, colIdx int, content string) string {
+	if this.cellStyle != nil {
+		content = this.cellStyle(rowIdx, this.origin(colIdx), content)
+	}
+	style := ""
+	if l := len(this.rowStyles); l > 0 {
+		style += this.rowStyles[rowIdx%l]
+	}
+	if settings := this.Column(colIdx); settings != nil {
+		style += settings.Style
+	}
+	if style == "" {
+		return content
+	} else if strings.Contains(style, "<") {
+		// style tokens are closed with a token, so that the formatter of the
+		// output resolves it, eg to nothing for monochrome output
+		return style + content + "<reset>"
+	}
+	return style + content + "\033[0m"
+}
+
 func (this *Table) checkAddCols(cols []string) error {
 	if this.Headers == nil {
 		return ErrHeadersNotSetYet
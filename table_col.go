@@ -30,7 +30,21 @@ func NewTableCol(row *TableRow, content string) *TableCol {
 // Render returns wrapped content, max content line width and content line count.
 // See `LineCount()`, `Width()` and `Content()` for more informations.
 func (this *TableCol) Render(maxWidth int) (content string, width, lineCount int) {
-	content = this.Content(maxWidth)
+	return this.RenderWithSettings(maxWidth, nil)
+}
+
+// RenderWithSettings is like `Render()`, but aligns and truncates the content
+// according to the given column settings, which can be nil.
+func (this *TableCol) RenderWithSettings(maxWidth int, settings *TableColumnSettings) (content string, width, lineCount int) {
+	if settings != nil && settings.Overflow == TABLE_OVERFLOW_TRUNCATE && maxWidth > 0 {
+		lines := strings.Split(this.renderedContent(), "\n")
+		for idx, line := range lines {
+			lines[idx] = TruncateString(line, maxWidth, TableEllipsis)
+		}
+		content = strings.Join(lines, "\n")
+	} else {
+		content = this.Content(maxWidth)
+	}
 	lines := strings.Split(content, "\n")
 	rendered := make([]string, len(lines))
 	for idx, line := range lines {
@@ -39,9 +53,7 @@ func (this *TableCol) Render(maxWidth int) (content string, width, lineCount int
 			width = lineLen
 		}
 		if maxWidth > 0 {
-			if diff := int(maxWidth) - lineLen; diff > 0 {
-				line += strings.Repeat(" ", diff)
-			}
+			line = settings.alignLine(line, maxWidth)
 		}
 		lineCount++
 		rendered[idx] = line
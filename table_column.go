@@ -0,0 +1,191 @@
+package clif
+
+import (
+	"regexp"
+	"strings"
+)
+
+type (
+	// TableAlign defines how contents are aligned within a column. See TABLE_ALIGN_*
+	TableAlign int
+
+	// TableOverflow defines how contents exceeding the column width are handled. See TABLE_OVERFLOW_*
+	TableOverflow int
+
+	// TableColumnSettings contain the rendering settings of a table column,
+	// which apply to the header and all contents of the column
+	TableColumnSettings struct {
+
+		// Align is the alignment of the contents. Defaults to left.
+		Align TableAlign
+
+		// Width is the fixed width of the column contents. If set, then
+		// `MinWidth` and `MaxWidth` are ignored.
+		Width int
+
+		// MinWidth is the minimal width of the column contents. With
+		// `TABLE_OVERFLOW_HIDE` it is the width below which the column is hidden.
+		MinWidth int
+
+		// MaxWidth is the maximal width of the column contents
+		MaxWidth int
+
+		// Overflow controls how contents exceeding the width are handled.
+		// Defaults to wrap.
+		Overflow TableOverflow
+
+		// Style is applied to all contents (not the header) of the column, eg
+		// "<info>" to highlight the column
+		Style string
+
+		// decimals is the max length of the decimal places (including the
+		// decimal point) of all contents, used for decimal alignment
+		decimals int
+
+		// numberWidth is the width required to decimal align all contents
+		numberWidth int
+	}
+)
+
+const (
+	// TABLE_ALIGN_LEFT aligns "foo" as "foo  "
+	TABLE_ALIGN_LEFT TableAlign = iota
+
+	// TABLE_ALIGN_RIGHT aligns "foo" as "  foo"
+	TABLE_ALIGN_RIGHT
+
+	// TABLE_ALIGN_CENTER aligns "foo" as " foo "
+	TABLE_ALIGN_CENTER
+
+	// TABLE_ALIGN_DECIMAL aligns contents on the decimal point and right
+	// aligns the result, eg "1.5" and "10.25" as " 1.5 " and "10.25"
+	TABLE_ALIGN_DECIMAL
+)
+
+const (
+	// TABLE_OVERFLOW_WRAP wraps contents into multiple lines
+	TABLE_OVERFLOW_WRAP TableOverflow = iota
+
+	// TABLE_OVERFLOW_TRUNCATE cuts contents and appends `TableEllipsis`
+	TABLE_OVERFLOW_TRUNCATE
+
+	// TABLE_OVERFLOW_HIDE hides the whole column, if the table is too narrow
+	// to render it in at least `MinWidth` (or, if not set, the header width).
+	// Contents which still exceed the width are wrapped.
+	TABLE_OVERFLOW_HIDE
+)
+
+var (
+	rxTableNumber = regexp.MustCompile(`^[-+]?[0-9][0-9,_']*(?:\.[0-9]*)?$`)
+
+	// TableEllipsis is appended to truncated contents
+	TableEllipsis = "…"
+)
+
+// NewTableColumnSettings constructs new column settings with left alignment
+// and wrapping overflow
+func NewTableColumnSettings() *TableColumnSettings {
+	return &TableColumnSettings{
+		Align:    TABLE_ALIGN_LEFT,
+		Overflow: TABLE_OVERFLOW_WRAP,
+	}
+}
+
+// SetAlign is builder method and sets the alignment
+func (this *TableColumnSettings) SetAlign(align TableAlign) *TableColumnSettings {
+	this.Align = align
+	return this
+}
+
+// SetWidth is builder method and sets the fixed width
+func (this *TableColumnSettings) SetWidth(width int) *TableColumnSettings {
+	this.Width = width
+	return this
+}
+
+// SetMinWidth is builder method and sets the minimal width
+func (this *TableColumnSettings) SetMinWidth(width int) *TableColumnSettings {
+	this.MinWidth = width
+	return this
+}
+
+// SetMaxWidth is builder method and sets the maximal width
+func (this *TableColumnSettings) SetMaxWidth(width int) *TableColumnSettings {
+	this.MaxWidth = width
+	return this
+}
+
+// SetOverflow is builder method and sets the overflow mode
+func (this *TableColumnSettings) SetOverflow(overflow TableOverflow) *TableColumnSettings {
+	this.Overflow = overflow
+	return this
+}
+
+// SetStyle is builder method and sets the style of the contents, which is
+// either style tokens (eg "<info>") or control characters
+func (this *TableColumnSettings) SetStyle(style string) *TableColumnSettings {
+	this.Style = style
+	return this
+}
+
+// clamp limits the given width to min and max width, or returns fixed width.
+// Decimal aligned columns are at least as wide as their widest number.
+func (this *TableColumnSettings) clamp(width int) int {
+	if this == nil {
+		return width
+	} else if this.Width > 0 {
+		return this.Width
+	}
+	if width < this.numberWidth {
+		width = this.numberWidth
+	}
+	if this.MaxWidth > 0 && width > this.MaxWidth {
+		width = this.MaxWidth
+	}
+	if width < this.MinWidth && this.Overflow != TABLE_OVERFLOW_HIDE {
+		width = this.MinWidth
+	}
+	return width
+}
+
+// alignLine pads a single (rendered) content line to the given width
+func (this *TableColumnSettings) alignLine(line string, width int) string {
+	align := TABLE_ALIGN_LEFT
+	if this != nil {
+		align = this.Align
+	}
+	if align == TABLE_ALIGN_DECIMAL {
+		align = TABLE_ALIGN_RIGHT
+		if decimals, ok := tableDecimalsLength(line); ok && this.decimals > 0 {
+			if diff := this.decimals - decimals; diff > 0 && StringLength(line)+diff <= width {
+				line += strings.Repeat(" ", diff)
+			}
+		}
+	}
+
+	diff := width - StringLength(line)
+	if diff <= 0 {
+		return line
+	}
+	switch align {
+	case TABLE_ALIGN_RIGHT:
+		return strings.Repeat(" ", diff) + line
+	case TABLE_ALIGN_CENTER:
+		return strings.Repeat(" ", diff/2) + line + strings.Repeat(" ", diff-diff/2)
+	default:
+		return line + strings.Repeat(" ", diff)
+	}
+}
+
+// tableDecimalsLength returns the length of the decimal places of a number,
+// including the decimal point, eg 3 for "1.25", and whether the string is a
+// number at all
+func tableDecimalsLength(s string) (int, bool) {
+	s = strings.TrimSpace(rxControlCharacters.ReplaceAllString(s, ""))
+	if !rxTableNumber.MatchString(s) {
+		return 0, false
+	} else if idx := strings.LastIndex(s, "."); idx > -1 {
+		return len(s[idx:]), true
+	}
+	return 0, true
+}
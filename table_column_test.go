@@ -0,0 +1,129 @@
+package clif
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+)
+
+func _testColumnTable() *Table {
+	style := CopyTableStyle(OpenTableStyle)
+	style.HeaderRenderer = func(content string) string { return content }
+	table := NewTable([]string{"Name", "Price", "Note"}, style)
+	table.AddRows([][]string{
+		{"foo", "1.5", "Some"},
+		{"barbaz", "10.25", "More"},
+		{"x", "100", "Even more"},
+	})
+	return table
+}
+
+func TestTableColumnAlign(t *testing.T) {
+	Convey("Align columns", t, func() {
+		table := _testColumnTable()
+		table.Column(0).SetAlign(TABLE_ALIGN_CENTER)
+		table.Column(1).SetAlign(TABLE_ALIGN_RIGHT)
+		So(table.Column(3), ShouldBeNil)
+
+		So(table.Render(30), ShouldEqual, strings.Join([]string{
+			"  Name  │ Price │ Note           ",
+			"────────┼───────┼────────────────",
+			"  foo   │   1.5 │ Some           ",
+			"────────┼───────┼────────────────",
+			" barbaz │ 10.25 │ More           ",
+			"────────┼───────┼────────────────",
+			"   x    │   100 │ Even more      ",
+			"",
+		}, "\n"))
+
+		Convey("Align on decimal point", func() {
+			table.Column(1).SetAlign(TABLE_ALIGN_DECIMAL)
+			So(table.Render(30), ShouldEqual, strings.Join([]string{
+				"  Name  │  Price │ Note          ",
+				"────────┼────────┼───────────────",
+				"  foo   │   1.5  │ Some          ",
+				"────────┼────────┼───────────────",
+				" barbaz │  10.25 │ More          ",
+				"────────┼────────┼───────────────",
+				"   x    │ 100    │ Even more     ",
+				"",
+			}, "\n"))
+		})
+	})
+}
+
+func TestTableColumnWidth(t *testing.T) {
+	Convey("Fixed, min and max widths", t, func() {
+		table := _testColumnTable()
+		table.Column(0).SetWidth(10)
+		table.Column(1).SetMinWidth(5)
+		table.Column(2).SetMaxWidth(16)
+
+		So(table.Render(40), ShouldEqual, strings.Join([]string{
+			" Name       │ Price     │ Note             ",
+			"────────────┼───────────┼──────────────────",
+			" foo        │ 1.5       │ Some             ",
+			"────────────┼───────────┼──────────────────",
+			" barbaz     │ 10.25     │ More             ",
+			"────────────┼───────────┼──────────────────",
+			" x          │ 100       │ Even more        ",
+			"",
+		}, "\n"))
+
+		Convey("Min width", func() {
+			table.Column(2).SetMaxWidth(9)
+			table.Column(1).SetMaxWidth(0).SetMinWidth(8)
+			widths := table.style.CalculateColWidths(table, 40)
+			So(widths[0], ShouldEqual, 10)
+			So(widths[1], ShouldBeGreaterThanOrEqualTo, 8)
+			So(widths[2], ShouldEqual, 9)
+		})
+	})
+}
+
+func TestTableColumnOverflow(t *testing.T) {
+	Convey("Truncate column contents", t, func() {
+		table := _testColumnTable()
+		table.Column(0).SetWidth(4).SetOverflow(TABLE_OVERFLOW_TRUNCATE)
+		table.Column(2).SetWidth(6).SetOverflow(TABLE_OVERFLOW_TRUNCATE)
+		So(table.Render(30), ShouldEqual, strings.Join([]string{
+			" Name │ Price           │ Note   ",
+			"──────┼─────────────────┼────────",
+			" foo  │ 1.5             │ Some   ",
+			"──────┼─────────────────┼────────",
+			" bar… │ 10.25           │ More   ",
+			"──────┼─────────────────┼────────",
+			" x    │ 100             │ Even … ",
+			"",
+		}, "\n"))
+	})
+
+	Convey("Hide column if too narrow", t, func() {
+		table := _testColumnTable()
+		So(table.hasHiddenOverflow(), ShouldBeFalse)
+		So(NewDefaultTableStyle().visibleColumns(table, 20), ShouldEqual, table)
+		table.Column(2).SetMinWidth(10).SetOverflow(TABLE_OVERFLOW_HIDE)
+		So(table.hasHiddenOverflow(), ShouldBeTrue)
+		So(table.Render(40), ShouldContainSubstring, "Note")
+		So(table.Render(20), ShouldEqual, strings.Join([]string{
+			" Name    │ Price       ",
+			"─────────┼─────────────",
+			" foo     │ 1.5         ",
+			"─────────┼─────────────",
+			" barbaz  │ 10.25       ",
+			"─────────┼─────────────",
+			" x       │ 100         ",
+			"",
+		}, "\n"))
+	})
+}
+
+func TestTruncateString(t *testing.T) {
+	Convey("Truncate strings", t, func() {
+		So(TruncateString("foo bar", 10, "…"), ShouldEqual, "foo bar")
+		So(TruncateString("foo bar", 5, "…"), ShouldEqual, "foo …")
+		So(TruncateString("foo bar", 5, "..."), ShouldEqual, "fo...")
+		So(TruncateString("foo bar", 2, "..."), ShouldEqual, "..")
+		So(TruncateString("\033[1mfoo\033[0m bar", 4, "…"), ShouldEqual, "\033[1mfoo…\033[0m")
+	})
+}
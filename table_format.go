@@ -0,0 +1,200 @@
+package clif
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+// TableRenderer renders a table into a string of a specific format
+type TableRenderer func(table *Table) (string, error)
+
+var (
+	// TableRenderers contain all formats a table can be rendered as with
+	// `RenderAs()`. Can be extended at users discretion.
+	TableRenderers = map[string]TableRenderer{
+		"text": func(table *Table) (string, error) {
+			return table.Render(), nil
+		},
+		"csv": func(table *Table) (string, error) {
+			return renderTableSeparated(table, ',')
+		},
+		"tsv": func(table *Table) (string, error) {
+			return renderTableSeparated(table, '\t')
+		},
+		"json":     renderTableJson,
+		"markdown": renderTableMarkdown,
+		"html":     renderTableHtml,
+	}
+)
+
+// RenderAs renders the table in the given format (see `TableRenderers`).
+// Except for "text", all style tokens are stripped from headers and contents.
+// The footer is rendered as last row, except for "json".
+func (this *Table) RenderAs(format string) (string, error) {
+	if renderer, ok := TableRenderers[format]; ok {
+		return renderer(this)
+	}
+	return "", fmt.Errorf("Unsupported table format \"%s\"", format)
+}
+
+// PlainHeaders returns the headers of the table, with style tokens stripped
+func (this *Table) PlainHeaders() []string {
+	if this.Headers == nil {
+		return []string{}
+	}
+	return plainTableRow(this.Headers)
+}
+
+// PlainRows returns the contents of all rows of the table, with style tokens
+// stripped
+func (this *Table) PlainRows() [][]string {
+	rows := make([][]string, len(this.Rows))
+	for idx, row := range this.Rows {
+		rows[idx] = plainTableRow(row)
+	}
+	return rows
+}
+
+// PlainFooter returns the footer of the table, with style tokens stripped, or
+// nil if the table has no footer
+func (this *Table) PlainFooter() []string {
+	if this.Footer == nil {
+		return nil
+	}
+	return plainTableRow(this.Footer)
+}
+
+// TableFormats returns the sorted names of all formats in `TableRenderers`
+func TableFormats() []string {
+	formats := make([]string, 0, len(TableRenderers))
+	for format, _ := range TableRenderers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// NewTableFormatOption returns the "--format" option, which accepts all
+// formats in `TableRenderers` and defaults to "text"
+func NewTableFormatOption() *Option {
+	return NewOption("format", "", "Output format ("+strings.Join(TableFormats(), ", ")+")", "text", false, false).
+		SetParse(func(name, value string) (string, error) {
+			if _, ok := TableRenderers[value]; !ok {
+				return "", fmt.Errorf("Unsupported format \"%s\", use one of %s", value, strings.Join(TableFormats(), ", "))
+			}
+			return value, nil
+		})
+}
+
+func plainTableRow(row *TableRow) []string {
+	formatter := NewDefaultFormatter(nil)
+	cols := make([]string, len(row.Cols))
+	for idx, col := range row.Cols {
+		if col != nil && col.content != nil {
+			cols[idx] = formatter.Format(*col.content)
+		}
+	}
+	return cols
+}
+
+func renderTableSeparated(table *Table, separator rune) (string, error) {
+	buf := bytes.NewBuffer(nil)
+	writer := csv.NewWriter(buf)
+	writer.Comma = separator
+	if err := writer.Write(table.PlainHeaders()); err != nil {
+		return "", err
+	}
+	rows := table.PlainRows()
+	if footer := table.PlainFooter(); footer != nil {
+		rows = append(rows, footer)
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func renderTableJson(table *Table) (string, error) {
+	headers := table.PlainHeaders()
+	keys := make([]string, len(headers))
+	for idx, header := range headers {
+		raw, err := json.Marshal(header)
+		if err != nil {
+			return "", err
+		}
+		keys[idx] = string(raw)
+	}
+
+	// encode manually to keep the order of the headers
+	objects := []string{}
+	for _, row := range table.PlainRows() {
+		fields := make([]string, len(row))
+		for idx, col := range row {
+			raw, err := json.Marshal(col)
+			if err != nil {
+				return "", err
+			}
+			fields[idx] = keys[idx] + ":" + string(raw)
+		}
+		objects = append(objects, "{"+strings.Join(fields, ",")+"}")
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := json.Indent(buf, []byte("["+strings.Join(objects, ",")+"]"), "", "  "); err != nil {
+		return "", err
+	}
+	return buf.String() + "\n", nil
+}
+
+func renderTableMarkdown(table *Table) (string, error) {
+	escape := strings.NewReplacer("|", `\|`, "\n", "<br>")
+	line := func(cols []string) string {
+		for idx, col := range cols {
+			cols[idx] = escape.Replace(col)
+		}
+		return "| " + strings.Join(cols, " | ") + " |\n"
+	}
+
+	headers := table.PlainHeaders()
+	out := line(headers)
+	separators := make([]string, len(headers))
+	for idx, _ := range separators {
+		separators[idx] = "---"
+	}
+	out += line(separators)
+	for _, row := range table.PlainRows() {
+		out += line(row)
+	}
+	if footer := table.PlainFooter(); footer != nil {
+		out += line(footer)
+	}
+	return out, nil
+}
+
+func renderTableHtml(table *Table) (string, error) {
+	line := func(tag string, cols []string) string {
+		for idx, col := range cols {
+			col = strings.Replace(html.EscapeString(col), "\n", "<br>", -1)
+			cols[idx] = "<" + tag + ">" + col + "</" + tag + ">"
+		}
+		return "    <tr>" + strings.Join(cols, "") + "</tr>\n"
+	}
+
+	out := "<table>\n  <thead>\n"
+	out += line("th", table.PlainHeaders())
+	out += "  </thead>\n  <tbody>\n"
+	for _, row := range table.PlainRows() {
+		out += line("td", row)
+	}
+	out += "  </tbody>\n"
+	if footer := table.PlainFooter(); footer != nil {
+		out += "  <tfoot>\n" + line("td", footer) + "  </tfoot>\n"
+	}
+	out += "</table>\n"
+	return out, nil
+}
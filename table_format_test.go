@@ -0,0 +1,91 @@
+package clif
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func _testFormatTable() *Table {
+	table := NewTable([]string{"Name", "<info>Note<reset>"})
+	table.AddRows([][]string{
+		{"<important>foo<reset>", "It's \"foo\""},
+		{"bar|baz", "Multi\nline <b>"},
+	})
+	return table
+}
+
+func TestTableRenderAs(t *testing.T) {
+	Convey("Render table as CSV", t, func() {
+		out, err := _testFormatTable().RenderAs("csv")
+		So(err, ShouldBeNil)
+		So(out, ShouldEqual, "Name,Note\nfoo,\"It's \"\"foo\"\"\"\nbar|baz,\"Multi\nline <b>\"\n")
+	})
+
+	Convey("Render table as TSV", t, func() {
+		out, err := _testFormatTable().RenderAs("tsv")
+		So(err, ShouldBeNil)
+		So(out, ShouldEqual, "Name\tNote\nfoo\t\"It's \"\"foo\"\"\"\nbar|baz\t\"Multi\nline <b>\"\n")
+	})
+
+	Convey("Render table as JSON", t, func() {
+		out, err := _testFormatTable().RenderAs("json")
+		So(err, ShouldBeNil)
+		So(out, ShouldEqual, `[
+  {
+    "Name": "foo",
+    "Note": "It's \"foo\""
+  },
+  {
+    "Name": "bar|baz",
+    "Note": "Multi\nline \u003cb\u003e"
+  }
+]
+`)
+		Convey("Empty table is empty array", func() {
+			out, err := NewTable([]string{"foo"}).RenderAs("json")
+			So(err, ShouldBeNil)
+			So(out, ShouldEqual, "[]\n")
+		})
+	})
+
+	Convey("Render table as Markdown", t, func() {
+		out, err := _testFormatTable().RenderAs("markdown")
+		So(err, ShouldBeNil)
+		So(out, ShouldEqual, "| Name | Note |\n| --- | --- |\n| foo | It's \"foo\" |\n| bar\\|baz | Multi<br>line <b> |\n")
+	})
+
+	Convey("Render table as HTML", t, func() {
+		out, err := _testFormatTable().RenderAs("html")
+		So(err, ShouldBeNil)
+		So(out, ShouldEqual, `<table>
+  <thead>
+    <tr><th>Name</th><th>Note</th></tr>
+  </thead>
+  <tbody>
+    <tr><td>foo</td><td>It&#39;s &#34;foo&#34;</td></tr>
+    <tr><td>bar|baz</td><td>Multi<br>line &lt;b&gt;</td></tr>
+  </tbody>
+</table>
+`)
+	})
+
+	Convey("Render table as text", t, func() {
+		table := _testFormatTable()
+		out, err := table.RenderAs("text")
+		So(err, ShouldBeNil)
+		So(out, ShouldEqual, table.Render())
+	})
+
+	Convey("Unsupported format fails", t, func() {
+		_, err := _testFormatTable().RenderAs("xml")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Format option", t, func() {
+		opt := NewTableFormatOption()
+		So(opt.Default, ShouldEqual, "text")
+		So(opt.Assign("json"), ShouldBeNil)
+		So(opt.String(), ShouldEqual, "json")
+		So(NewTableFormatOption().Assign("xml"), ShouldNotBeNil)
+	})
+}
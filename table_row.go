@@ -66,7 +66,7 @@ func (this *TableRow) RenderWithWidths(colWidths []int) (rendered []string, maxL
 	//fmt.Printf("\nRENDER ROW WITH COL WIDTHS %v\n", colWidths)
 	for idx, col := range this.Cols {
 		//content, renderWidth, lineCount := col.Render(colWidths[idx])
-		content, _, lineCount := col.Render(colWidths[idx])
+		content, _, lineCount := col.RenderWithSettings(colWidths[idx], this.column(idx))
 		m := 0
 		for _, c := range strings.Split(content, "\n") {
 			if l := len(c); l > m {
@@ -82,6 +82,15 @@ func (this *TableRow) RenderWithWidths(colWidths []int) (rendered []string, maxL
 	return
 }
 
+// column returns the column settings of the col with the given index, if the
+// row belongs to a table
+func (this *TableRow) column(idx int) *TableColumnSettings {
+	if this.table == nil {
+		return nil
+	}
+	return this.table.Column(idx)
+}
+
 func (this *TableRow) SetTable(table *Table) *TableRow {
 	this.table = table
 	return this
@@ -105,3 +114,60 @@ func (this *TableRow) SetRenderer(renderer func(string) string) *TableRow {
 	}
 	return this
 }
+
+// SetColSpan sets the amount of cols the col with the given index spans,
+// including itself. The contents of the following, spanned cols are not
+// rendered.
+func (this *TableRow) SetColSpan(idx, span int) error {
+	if idx < 0 || idx >= this.ColAmount {
+		return fmt.Errorf("Column index %d is beyond colum size %d", idx, this.ColAmount)
+	} else if span < 1 || idx+span > this.ColAmount {
+		return fmt.Errorf("Column %d cannot span %d columns of %d", idx, span, this.ColAmount)
+	}
+	if this.spans == nil {
+		this.spans = make([]int, this.ColAmount)
+		for i, _ := range this.spans {
+			this.spans[i] = 1
+		}
+	}
+	this.spans[idx] = span
+	return nil
+}
+
+// ColSpan returns the amount of cols the col with the given index spans, which
+// is 1 per default and 0 if the col is spanned by a previous col
+func (this *TableRow) ColSpan(idx int) int {
+	if this.spans == nil {
+		return 1
+	}
+	for i := 0; i < idx; {
+		if i+this.spans[i] > idx {
+			return 0
+		}
+		i += this.spans[i]
+	}
+	return this.spans[idx]
+}
+
+// hasBoundary returns whether there is a vertical line between the col with
+// the given index and the following col
+func (this *TableRow) hasBoundary(idx int) bool {
+	return this.ColSpan(idx+1) > 0
+}
+
+// projectSpans returns the col spans for a row, which contains only the cols
+// with the given indices
+func (this *TableRow) projectSpans(indices []int) []int {
+	if this.spans == nil {
+		return nil
+	}
+	spans := make([]int, len(indices))
+	for idx, colIdx := range indices {
+		spans[idx] = 1
+		span := this.ColSpan(colIdx)
+		for i := idx + 1; span > 1 && i < len(indices) && indices[i] == indices[i-1]+1 && indices[i] < colIdx+span; i++ {
+			spans[idx]++
+		}
+	}
+	return spans
+}
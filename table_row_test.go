@@ -108,4 +108,29 @@ func TestTableRow(t *testing.T) {
 			})
 		}
 	})
-}
\ No newline at end of file
+}
+func TestTableRowColSpan(t *testing.T) {
+	Convey("Span cols", t, func() {
+		row := NewTableRow([]string{"foo", "bar", "baz", "qux"})
+		So(row.ColSpan(1), ShouldEqual, 1)
+		So(row.SetColSpan(1, 2), ShouldBeNil)
+		So(row.ColSpan(0), ShouldEqual, 1)
+		So(row.ColSpan(1), ShouldEqual, 2)
+		So(row.ColSpan(2), ShouldEqual, 0)
+		So(row.ColSpan(3), ShouldEqual, 1)
+		So(row.hasBoundary(0), ShouldBeTrue)
+		So(row.hasBoundary(1), ShouldBeFalse)
+
+		Convey("Spans beyond the row fail", func() {
+			So(row.SetColSpan(3, 2), ShouldNotBeNil)
+			So(row.SetColSpan(4, 1), ShouldNotBeNil)
+			So(row.SetColSpan(0, 0), ShouldNotBeNil)
+		})
+
+		Convey("Spans are projected to selected cols", func() {
+			So(row.projectSpans([]int{0, 1, 2, 3}), ShouldResemble, []int{1, 2, 1, 1})
+			So(row.projectSpans([]int{1, 3}), ShouldResemble, []int{1, 1})
+			So(row.projectSpans([]int{2, 1}), ShouldResemble, []int{1, 1})
+		})
+	})
+}
@@ -0,0 +1,366 @@
+package clif
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type (
+	// TableSortMode defines how the contents of a column are compared when
+	// sorting. See TABLE_SORT_*
+	TableSortMode int
+
+	// TableSortKey describes a column a table is sorted by
+	TableSortKey struct {
+
+		// Column is the index of the column
+		Column int
+
+		// Mode is the comparison of the column contents. Defaults to string.
+		Mode TableSortMode
+
+		// Descending reverses the order
+		Descending bool
+	}
+)
+
+const (
+	// TABLE_SORT_STRING compares contents lexically, eg "a10" < "a9"
+	TABLE_SORT_STRING TableSortMode = iota
+
+	// TABLE_SORT_NUMERIC compares contents as numbers. Contents which are not
+	// numbers are sorted lexically after all numbers.
+	TABLE_SORT_NUMERIC
+
+	// TABLE_SORT_NATURAL compares contents lexically, but sequences of digits
+	// numerically, eg "a9" < "a10"
+	TABLE_SORT_NATURAL
+)
+
+var (
+	// TableSortModes maps the names of sort modes, as used in `SortBy()`, to
+	// the modes
+	TableSortModes = map[string]TableSortMode{
+		"string":  TABLE_SORT_STRING,
+		"numeric": TABLE_SORT_NUMERIC,
+		"natural": TABLE_SORT_NATURAL,
+	}
+)
+
+// NewTableSortKey constructs a new ascending, lexical sort key for the column
+// with the given index
+func NewTableSortKey(column int) *TableSortKey {
+	return &TableSortKey{
+		Column: column,
+		Mode:   TABLE_SORT_STRING,
+	}
+}
+
+// SetMode is builder method and sets the comparison mode
+func (this *TableSortKey) SetMode(mode TableSortMode) *TableSortKey {
+	this.Mode = mode
+	return this
+}
+
+// SetDescending is builder method and sets whether the order is reversed
+func (this *TableSortKey) SetDescending(descending bool) *TableSortKey {
+	this.Descending = descending
+	return this
+}
+
+// compare returns -1, 0 or 1 if a is less, equal or greater than b
+func (this *TableSortKey) compare(a, b string) int {
+	var res int
+	switch this.Mode {
+	case TABLE_SORT_NUMERIC:
+		res = compareTableNumeric(a, b)
+	case TABLE_SORT_NATURAL:
+		res = compareTableNatural(a, b)
+	default:
+		res = strings.Compare(a, b)
+	}
+	if this.Descending {
+		return -res
+	}
+	return res
+}
+
+// ColumnIndex returns the index of the column with the given header (case
+// insensitive, style tokens are ignored) or -1, if there is no such column
+func (this *Table) ColumnIndex(name string) int {
+	for idx, header := range this.PlainHeaders() {
+		if strings.EqualFold(header, name) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// Sort sorts the rows of the table by the given keys. Rows which are equal
+// regarding the first key are compared by the second key and so on. Rows
+// which are equal regarding all keys keep their order. Style tokens are
+// ignored when comparing. Groups of rows (see `AddSeparator()`) are sorted
+// separately and stay in their order.
+func (this *Table) Sort(keys ...*TableSortKey) error {
+	for _, key := range keys {
+		if key.Column < 0 || key.Column >= this.colAmount {
+			return fmt.Errorf("Cannot sort by column %d -> Only %d columns in table", key.Column, this.colAmount)
+		}
+	}
+	start := 0
+	for end := 1; end <= len(this.Rows); end++ {
+		if end < len(this.Rows) && !this.Rows[end].Separator {
+			continue
+		}
+		separator := this.Rows[start].Separator
+		this.Rows[start].Separator = false
+		sorter := &tableRowsSort{
+			keys:  keys,
+			plain: make([][]string, end-start),
+			rows:  this.Rows[start:end],
+		}
+		for idx, row := range sorter.rows {
+			sorter.plain[idx] = plainTableRow(row)
+		}
+		sort.Stable(sorter)
+		this.Rows[start].Separator = separator
+		start = end
+	}
+	return nil
+}
+
+// SortBy sorts the table by a comma separated list of column headers. A
+// header can be prefixed with "-" to sort descending and suffixed with a
+// mode from `TableSortModes`, eg "-price:numeric,name".
+func (this *Table) SortBy(spec string) error {
+	keys := make([]*TableSortKey, 0)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key := NewTableSortKey(0)
+		if strings.HasPrefix(part, "-") {
+			key.SetDescending(true)
+			part = part[1:]
+		} else if strings.HasPrefix(part, "+") {
+			part = part[1:]
+		}
+		if idx := strings.LastIndex(part, ":"); idx > -1 {
+			mode, ok := TableSortModes[strings.ToLower(part[idx+1:])]
+			if !ok {
+				return fmt.Errorf("Unsupported sort mode \"%s\"", part[idx+1:])
+			}
+			key.SetMode(mode)
+			part = part[0:idx]
+		}
+		if key.Column = this.ColumnIndex(part); key.Column == -1 {
+			return fmt.Errorf("Cannot sort by unknown column \"%s\"", part)
+		}
+		keys = append(keys, key)
+	}
+	return this.Sort(keys...)
+}
+
+// Filter removes all rows from the table, for which the predicate returns
+// false. The predicate receives the contents of the row with style tokens
+// stripped. Separators of removed rows are kept.
+func (this *Table) Filter(predicate func(cols []string) bool) *Table {
+	rows := make([]*TableRow, 0, len(this.Rows))
+	separator := false
+	for _, row := range this.Rows {
+		separator = separator || row.Separator
+		if predicate(plainTableRow(row)) {
+			row.Separator = separator
+			separator = false
+			rows = append(rows, row)
+		}
+	}
+	this.Rows = rows
+	this.rowAmount = len(rows)
+	return this
+}
+
+// SelectColumns returns a new table, which contains only the columns with
+// the given indices, in the given order. Column settings, styles, footer,
+// separators and col spans (of adjacent columns) are copied. Cell style
+// callbacks keep receiving the column indices of the original table.
+func (this *Table) SelectColumns(indices ...int) (*Table, error) {
+	if this.Headers == nil {
+		return nil, ErrHeadersNotSetYet
+	}
+	for _, idx := range indices {
+		if idx < 0 || idx >= this.colAmount {
+			return nil, fmt.Errorf("Cannot select column %d -> Only %d columns in table", idx, this.colAmount)
+		}
+	}
+	project := func(row *TableRow) []string {
+		cols := make([]string, len(indices))
+		for idx, colIdx := range indices {
+			if col := row.Cols[colIdx]; col != nil && col.content != nil {
+				cols[idx] = *col.content
+			}
+		}
+		return cols
+	}
+
+	table := NewTable(project(this.Headers), this.style)
+	table.AllowEmptyFill = this.AllowEmptyFill
+	table.rowStyles = this.rowStyles
+	table.cellStyle = this.cellStyle
+	table.origins = make([]int, len(indices))
+	table.Headers.spans = this.Headers.projectSpans(indices)
+	for idx, colIdx := range indices {
+		settings := *this.columns[colIdx]
+		table.columns[idx] = &settings
+		table.origins[idx] = this.origin(colIdx)
+	}
+	for _, row := range this.Rows {
+		table.separatorPending = row.Separator
+		table.addRow(project(row))
+		table.Rows[len(table.Rows)-1].spans = row.projectSpans(indices)
+	}
+	if this.Footer != nil {
+		table.SetFooter(project(this.Footer))
+		table.Footer.spans = this.Footer.projectSpans(indices)
+	}
+	return table, nil
+}
+
+// SelectColumnsByName is like `SelectColumns()`, but selects columns by
+// their headers (see `ColumnIndex()`)
+func (this *Table) SelectColumnsByName(names ...string) (*Table, error) {
+	indices := make([]int, len(names))
+	for idx, name := range names {
+		if indices[idx] = this.ColumnIndex(name); indices[idx] == -1 {
+			return nil, fmt.Errorf("Cannot select unknown column \"%s\"", name)
+		}
+	}
+	return this.SelectColumns(indices...)
+}
+
+// ApplyOptions sorts the table and selects columns according to the values
+// of the "--sort" and "--columns" options of the given command, if set. See
+// `NewTableSortOption()` and `NewTableColumnsOption()`. Returns the table with
+// the selected columns.
+func (this *Table) ApplyOptions(c *Command) (*Table, error) {
+	if opt := c.Option("sort"); opt != nil && opt.String() != "" {
+		if err := this.SortBy(opt.String()); err != nil {
+			return nil, err
+		}
+	}
+	if opt := c.Option("columns"); opt != nil && opt.String() != "" {
+		names := make([]string, 0)
+		for _, name := range strings.Split(opt.String(), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		return this.SelectColumnsByName(names...)
+	}
+	return this, nil
+}
+
+// NewTableColumnsOption returns the "--columns" option, which selects the
+// columns of a table (see `ApplyOptions()`)
+func NewTableColumnsOption() *Option {
+	return NewOption("columns", "", "Comma separated list of columns to show", "", false, false)
+}
+
+// NewTableSortOption returns the "--sort" option, which sorts the rows of a
+// table (see `ApplyOptions()` and `SortBy()`)
+func NewTableSortOption() *Option {
+	return NewOption("sort", "", "Comma separated list of columns to sort by, eg \"-price:numeric,name\"", "", false, false)
+}
+
+func compareTableNumeric(a, b string) int {
+	na, errA := parseTableNumber(a)
+	nb, errB := parseTableNumber(b)
+	switch {
+	case errA == nil && errB == nil:
+		if na < nb {
+			return -1
+		} else if na > nb {
+			return 1
+		}
+		return 0
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func parseTableNumber(s string) (float64, error) {
+	s = strings.NewReplacer(",", "", "_", "", "'", "").Replace(strings.TrimSpace(s))
+	return strconv.ParseFloat(s, 64)
+}
+
+func compareTableNatural(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	for len(ra) > 0 && len(rb) > 0 {
+		if unicode.IsDigit(ra[0]) && unicode.IsDigit(rb[0]) {
+			da, db := tableDigits(ra), tableDigits(rb)
+			na := strings.TrimLeft(string(ra[0:da]), "0")
+			nb := strings.TrimLeft(string(rb[0:db]), "0")
+			if len(na) != len(nb) {
+				if len(na) < len(nb) {
+					return -1
+				}
+				return 1
+			} else if res := strings.Compare(na, nb); res != 0 {
+				return res
+			}
+			ra, rb = ra[da:], rb[db:]
+		} else if ra[0] != rb[0] {
+			if ra[0] < rb[0] {
+				return -1
+			}
+			return 1
+		} else {
+			ra, rb = ra[1:], rb[1:]
+		}
+	}
+	if len(ra) < len(rb) {
+		return -1
+	} else if len(ra) > len(rb) {
+		return 1
+	}
+	return 0
+}
+
+func tableDigits(r []rune) int {
+	idx := 0
+	for idx < len(r) && unicode.IsDigit(r[idx]) {
+		idx++
+	}
+	return idx
+}
+
+type tableRowsSort struct {
+	keys  []*TableSortKey
+	plain [][]string
+	rows  []*TableRow
+}
+
+func (this *tableRowsSort) Len() int {
+	return len(this.rows)
+}
+
+func (this *tableRowsSort) Less(i, j int) bool {
+	for _, key := range this.keys {
+		if res := key.compare(this.plain[i][key.Column], this.plain[j][key.Column]); res != 0 {
+			return res < 0
+		}
+	}
+	return false
+}
+
+func (this *tableRowsSort) Swap(i, j int) {
+	this.rows[i], this.rows[j] = this.rows[j], this.rows[i]
+	this.plain[i], this.plain[j] = this.plain[j], this.plain[i]
+}
@@ -0,0 +1,139 @@
+package clif
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func _testSortTable() *Table {
+	table := NewTable([]string{"Name", "<info>Price<reset>", "Size"})
+	table.AddRows([][]string{
+		{"file10", "10", "b"},
+		{"<important>file9<reset>", "9.5", "a"},
+		{"file1", "n/a", "b"},
+		{"file100", "1,000", "a"},
+	})
+	return table
+}
+
+func _testTableColumn(table *Table, idx int) []string {
+	res := make([]string, len(table.Rows))
+	for i, row := range table.PlainRows() {
+		res[i] = row[idx]
+	}
+	return res
+}
+
+func TestTableSort(t *testing.T) {
+	Convey("Sort table rows", t, func() {
+		table := _testSortTable()
+
+		Convey("Lexically", func() {
+			So(table.Sort(NewTableSortKey(0)), ShouldBeNil)
+			So(_testTableColumn(table, 0), ShouldResemble, []string{"file1", "file10", "file100", "file9"})
+		})
+
+		Convey("Naturally", func() {
+			So(table.Sort(NewTableSortKey(0).SetMode(TABLE_SORT_NATURAL)), ShouldBeNil)
+			So(_testTableColumn(table, 0), ShouldResemble, []string{"file1", "file9", "file10", "file100"})
+		})
+
+		Convey("Numerically, descending", func() {
+			So(table.Sort(NewTableSortKey(1).SetMode(TABLE_SORT_NUMERIC).SetDescending(true)), ShouldBeNil)
+			So(_testTableColumn(table, 1), ShouldResemble, []string{"n/a", "1,000", "10", "9.5"})
+		})
+
+		Convey("By multiple columns, stable", func() {
+			So(table.Sort(NewTableSortKey(2)), ShouldBeNil)
+			So(_testTableColumn(table, 0), ShouldResemble, []string{"file9", "file100", "file10", "file1"})
+			So(table.Sort(NewTableSortKey(2).SetDescending(true), NewTableSortKey(0).SetMode(TABLE_SORT_NATURAL)), ShouldBeNil)
+			So(_testTableColumn(table, 0), ShouldResemble, []string{"file1", "file10", "file9", "file100"})
+		})
+
+		Convey("By spec", func() {
+			So(table.SortBy("size, -price:numeric"), ShouldBeNil)
+			So(_testTableColumn(table, 0), ShouldResemble, []string{"file100", "file9", "file1", "file10"})
+		})
+
+		Convey("Within groups of rows", func() {
+			table.AddSeparator().AddRows([][]string{
+				{"file3", "3", "a"},
+				{"file2", "2", "a"},
+			})
+			So(table.SortBy("name:natural"), ShouldBeNil)
+			So(_testTableColumn(table, 0), ShouldResemble, []string{"file1", "file9", "file10", "file100", "file2", "file3"})
+			separators := []bool{}
+			for _, row := range table.Rows {
+				separators = append(separators, row.Separator)
+			}
+			So(separators, ShouldResemble, []bool{false, false, false, false, true, false})
+		})
+
+		Convey("Fails for unknown columns or modes", func() {
+			So(table.Sort(NewTableSortKey(3)), ShouldNotBeNil)
+			So(table.SortBy("foo"), ShouldNotBeNil)
+			So(table.SortBy("name:foo"), ShouldNotBeNil)
+		})
+	})
+}
+
+func TestTableFilter(t *testing.T) {
+	Convey("Filter table rows", t, func() {
+		table := _testSortTable().Filter(func(cols []string) bool {
+			return cols[2] == "a"
+		})
+		So(_testTableColumn(table, 0), ShouldResemble, []string{"file9", "file100"})
+		So(table.rowAmount, ShouldEqual, 2)
+	})
+}
+
+func TestTableSelectColumns(t *testing.T) {
+	Convey("Select table columns", t, func() {
+		table := _testSortTable()
+		table.Column(2).SetAlign(TABLE_ALIGN_RIGHT)
+
+		selected, err := table.SelectColumns(2, 0)
+		So(err, ShouldBeNil)
+		So(selected.PlainHeaders(), ShouldResemble, []string{"Size", "Name"})
+		So(selected.PlainRows()[1], ShouldResemble, []string{"a", "file9"})
+		So(*selected.Rows[1].Cols[1].content, ShouldEqual, "<important>file9<reset>")
+		So(selected.Column(0).Align, ShouldEqual, TABLE_ALIGN_RIGHT)
+		So(table.colAmount, ShouldEqual, 3)
+
+		Convey("By name", func() {
+			selected, err := table.SelectColumnsByName("price", "NAME")
+			So(err, ShouldBeNil)
+			So(selected.PlainHeaders(), ShouldResemble, []string{"Price", "Name"})
+		})
+
+		Convey("Fails for unknown columns", func() {
+			_, err := table.SelectColumns(3)
+			So(err, ShouldNotBeNil)
+			_, err = table.SelectColumnsByName("foo")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestTableApplyOptions(t *testing.T) {
+	Convey("Sort and select columns from options", t, func() {
+		cmd := NewCommand("foo", "", func() {}).
+			AddOption(NewTableColumnsOption()).
+			AddOption(NewTableSortOption())
+
+		table, err := _testSortTable().ApplyOptions(cmd)
+		So(err, ShouldBeNil)
+		So(table.PlainHeaders(), ShouldResemble, []string{"Name", "Price", "Size"})
+
+		cmd.Option("sort").Assign("name:natural")
+		cmd.Option("columns").Assign("size,name")
+		table, err = _testSortTable().ApplyOptions(cmd)
+		So(err, ShouldBeNil)
+		So(table.PlainHeaders(), ShouldResemble, []string{"Size", "Name"})
+		So(_testTableColumn(table, 1), ShouldResemble, []string{"file1", "file9", "file10", "file100"})
+
+		cmd.Option("columns").Values = []string{"foo"}
+		_, err = _testSortTable().ApplyOptions(cmd)
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,169 @@
+package clif
+
+import (
+	"fmt"
+	"io"
+)
+
+// TableStream renders a table row by row into a writer, without keeping all
+// rows in memory. Column widths are either fixed up front (see
+// `SetColWidths()`) or calculated from the first rows (see `SetSampleSize()`),
+// which are buffered until the sample is complete. All following rows are
+// written as they are added. Contents exceeding the column widths are wrapped.
+type TableStream struct {
+
+	// Headers of the table
+	Headers *TableRow
+
+	// colAmount is the amount of cols per row (fixed size)
+	colAmount int
+
+	// colWidths are the widths of the cols, either fixed or calculated
+	colWidths []int
+
+	// headers are the original headers, used to calculate widths
+	headers []string
+
+	// last is the last written row
+	last *TableRow
+
+	// maxWidth is the total width of the table (0 = terminal width)
+	maxWidth int
+
+	// out is the writer the table is written to
+	out io.Writer
+
+	// rowAmount is the amount of rows added
+	rowAmount int
+
+	// sample contains the buffered rows used to calculate widths
+	sample [][]string
+
+	// sampleSize is the amount of rows used to calculate widths
+	sampleSize int
+
+	// started is true after the header has been written
+	started bool
+
+	// Style for rendering table
+	style *TableStyle
+}
+
+var (
+	// TableStreamSampleSize is the default amount of rows, which are used to
+	// calculate the column widths of a `TableStream`
+	TableStreamSampleSize = 100
+)
+
+// NewTableStream constructs a new table stream, which writes into the given writer
+func NewTableStream(out io.Writer, headers []string, style ...*TableStyle) *TableStream {
+	if len(style) == 0 {
+		style = []*TableStyle{NewDefaultTableStyle()}
+	}
+	return &TableStream{
+		Headers:    NewTableRow(headers),
+		colAmount:  len(headers),
+		headers:    headers,
+		out:        out,
+		sample:     make([][]string, 0),
+		sampleSize: TableStreamSampleSize,
+		style:      style[0],
+	}
+}
+
+// SetColWidths is builder method and fixes the content widths of all columns,
+// so that no rows need to be buffered. The amount of widths must equal the
+// amount of headers.
+func (this *TableStream) SetColWidths(widths ...int) *TableStream {
+	this.colWidths = widths
+	return this
+}
+
+// SetMaxWidth is builder method and sets the total width of the table, which
+// is used if the column widths are calculated. Defaults to the terminal width.
+func (this *TableStream) SetMaxWidth(width int) *TableStream {
+	this.maxWidth = width
+	return this
+}
+
+// SetSampleSize is builder method and sets the amount of rows, which are used
+// to calculate the column widths (see `TableStreamSampleSize`)
+func (this *TableStream) SetSampleSize(size int) *TableStream {
+	this.sampleSize = size
+	return this
+}
+
+// AddRow adds another row. The row is written immediately, unless it is part
+// of the sample to calculate column widths.
+func (this *TableStream) AddRow(cols []string) error {
+	if l := len(cols); l != this.colAmount {
+		return fmt.Errorf("Cannot add %d cols. Expected width is %d", l, this.colAmount)
+	}
+	this.rowAmount++
+	if !this.started {
+		this.sample = append(this.sample, cols)
+		if this.colWidths == nil && len(this.sample) < this.sampleSize {
+			return nil
+		}
+		return this.Flush()
+	}
+	return this.writeRow(cols)
+}
+
+// Flush writes the header and all buffered rows. Column widths are calculated
+// from the buffered rows, if not fixed.
+func (this *TableStream) Flush() error {
+	if !this.started {
+		if this.colWidths == nil {
+			table := NewTable(this.headers, this.style)
+			if err := table.AddRows(this.sample); err != nil {
+				return err
+			}
+			this.colWidths = this.style.CalculateColWidths(table, this.maxWidth)
+		} else if l := len(this.colWidths); l != this.colAmount {
+			return fmt.Errorf("Cannot use %d col widths. Expected width is %d", l, this.colAmount)
+		}
+		this.started = true
+		out := this.style.renderTopRow(this.Headers, this.colWidths)
+		out += this.style.renderHeaderRow(this.Headers, this.colWidths)
+		this.last = this.Headers
+		if err := this.write(out); err != nil {
+			return err
+		}
+	}
+	for _, cols := range this.sample {
+		if err := this.writeRow(cols); err != nil {
+			return err
+		}
+	}
+	this.sample = make([][]string, 0)
+	return nil
+}
+
+// Close flushes all buffered rows and writes the bottom of the table
+func (this *TableStream) Close() error {
+	if err := this.Flush(); err != nil {
+		return err
+	}
+	if bottom := this.style.renderBottomRow(this.last, this.colWidths); bottom != "" {
+		return this.write(bottom + "\n")
+	}
+	return nil
+}
+
+// RowAmount returns the amount of rows added so far
+func (this *TableStream) RowAmount() int {
+	return this.rowAmount
+}
+
+func (this *TableStream) writeRow(cols []string) error {
+	row := NewTableRow(cols).SetRenderer(this.style.ContentRenderer)
+	out := this.style.renderDataRow(this.last, row, this.colWidths)
+	this.last = row
+	return this.write(out)
+}
+
+func (this *TableStream) write(s string) error {
+	_, err := io.WriteString(this.out, s)
+	return err
+}
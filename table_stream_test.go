@@ -0,0 +1,81 @@
+package clif
+
+import (
+	"bytes"
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"testing"
+)
+
+func TestTableStream(t *testing.T) {
+	headers := []string{"Name", "Description"}
+	rows := [][]string{
+		{"foo", "The foo"},
+		{"bar", "The bar, which is a bit longer"},
+		{"baz", "The baz"},
+	}
+
+	Convey("Streaming table equals table, if sample contains all rows", t, func() {
+		table := NewTable(headers, CopyTableStyle(ClosedTableStyle))
+		table.AddRows(rows)
+
+		buf := bytes.NewBuffer(nil)
+		stream := NewTableStream(buf, headers, CopyTableStyle(ClosedTableStyle)).SetMaxWidth(40)
+		for _, row := range rows {
+			So(stream.AddRow(row), ShouldBeNil)
+		}
+		So(buf.Len(), ShouldEqual, 0)
+		So(stream.Close(), ShouldBeNil)
+		So(buf.String(), ShouldEqual, table.Render(40))
+		So(stream.RowAmount(), ShouldEqual, 3)
+	})
+
+	Convey("Rows after sample are written immediately", t, func() {
+		buf := bytes.NewBuffer(nil)
+		stream := NewTableStream(buf, headers, CopyTableStyle(OpenTableStyle)).
+			SetMaxWidth(40).
+			SetSampleSize(2)
+		stream.AddRow(rows[0])
+		So(buf.Len(), ShouldEqual, 0)
+		stream.AddRow(rows[1])
+		So(buf.String(), ShouldContainSubstring, "The bar")
+		stream.AddRow(rows[2])
+		So(buf.String(), ShouldContainSubstring, "The baz")
+		So(stream.Close(), ShouldBeNil)
+	})
+
+	Convey("Fixed column widths", t, func() {
+		buf := bytes.NewBuffer(nil)
+		style := CopyTableStyle(OpenTableStyle)
+		style.HeaderRenderer = func(content string) string { return content }
+		stream := NewTableStream(buf, headers, style).SetColWidths(4, 10)
+		stream.AddRow(rows[0])
+		So(buf.String(), ShouldEqual, strings.Join([]string{
+			" Name │ Descriptio ",
+			"      │ n          ",
+			"──────┼────────────",
+			" foo  │ The foo    ",
+			"",
+		}, "\n"))
+		So(stream.Close(), ShouldBeNil)
+
+		Convey("Widths must match columns", func() {
+			stream := NewTableStream(buf, headers).SetColWidths(4)
+			So(stream.AddRow(rows[0]), ShouldNotBeNil)
+		})
+	})
+
+	Convey("Rows must match columns", t, func() {
+		stream := NewTableStream(bytes.NewBuffer(nil), headers)
+		So(stream.AddRow([]string{"foo"}), ShouldNotBeNil)
+	})
+
+	Convey("Table stream from output", t, func() {
+		buf := bytes.NewBuffer(nil)
+		out := NewMonochromeOutput(buf)
+		stream := out.TableStream(headers)
+		stream.AddRow(rows[0])
+		So(stream.Close(), ShouldBeNil)
+		So(buf.String(), ShouldContainSubstring, "The foo")
+	})
+}
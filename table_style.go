@@ -7,88 +7,108 @@ import (
 
 var (
 	ClosedTableStyle = &TableStyle{
-		Bottom:          "─",
-		ContentRenderer: func(content string) string { return content },
-		CrossBottom:     "┴",
-		CrossInner:      "┼",
-		CrossLeft:       "├",
-		CrossRight:      "┤",
-		CrossTop:        "┬",
-		HeaderRenderer:  func(content string) string { return fmt.Sprintf("\033[1;4m%s\033[0m", content) },
-		InnerHorizontal: "─",
-		InnerVertical:   "│",
-		Left:            "│",
-		LeftBottom:      "└",
-		LeftTop:         "┌",
-		Prefix:          " ",
-		Right:           "│",
-		RightBottom:     "┘",
-		RightTop:        "┐",
-		Suffix:          " ",
-		Top:             "─",
+		Bottom:              "─",
+		ContentRenderer:     func(content string) string { return content },
+		CrossBottom:         "┴",
+		CrossInner:          "┼",
+		CrossLeft:           "├",
+		CrossRight:          "┤",
+		CrossTop:            "┬",
+		FooterRenderer:      func(content string) string { return fmt.Sprintf("\033[1m%s\033[0m", content) },
+		HeaderRenderer:      func(content string) string { return fmt.Sprintf("\033[1;4m%s\033[0m", content) },
+		InnerHorizontal:     "─",
+		InnerVertical:       "│",
+		Left:                "│",
+		LeftBottom:          "└",
+		LeftTop:             "┌",
+		Prefix:              " ",
+		Right:               "│",
+		RightBottom:         "┘",
+		RightTop:            "┐",
+		SeparatorCross:      "╪",
+		SeparatorHorizontal: "═",
+		SeparatorLeft:       "╞",
+		SeparatorRight:      "╡",
+		Suffix:              " ",
+		Top:                 "─",
 	}
 	ClosedTableStyleLight = &TableStyle{
-		Bottom:          "\033[38;5;234m─\033[0m",
-		ContentRenderer: func(content string) string { return content },
-		CrossBottom:     "\033[38;5;234m┴\033[0m",
-		CrossInner:      "\033[38;5;234m┼\033[0m",
-		CrossLeft:       "\033[38;5;234m├\033[0m",
-		CrossRight:      "\033[38;5;234m┤\033[0m",
-		CrossTop:        "\033[38;5;234m┬\033[0m",
-		HeaderRenderer:  func(content string) string { return fmt.Sprintf("\033[1;4m%s\033[0m", content) },
-		InnerHorizontal: "\033[38;5;234m─\033[0m",
-		InnerVertical:   "\033[38;5;234m│\033[0m",
-		Left:            "\033[38;5;234m│\033[0m",
-		LeftBottom:      "\033[38;5;234m└\033[0m",
-		LeftTop:         "\033[38;5;234m┌\033[0m",
-		Prefix:          " ",
-		Right:           "\033[38;5;234m│\033[0m",
-		RightBottom:     "\033[38;5;234m┘\033[0m",
-		RightTop:        "\033[38;5;234m┐\033[0m",
-		Suffix:          " ",
-		Top:             "\033[38;5;234m─\033[0m",
+		Bottom:              "\033[38;5;234m─\033[0m",
+		ContentRenderer:     func(content string) string { return content },
+		CrossBottom:         "\033[38;5;234m┴\033[0m",
+		CrossInner:          "\033[38;5;234m┼\033[0m",
+		CrossLeft:           "\033[38;5;234m├\033[0m",
+		CrossRight:          "\033[38;5;234m┤\033[0m",
+		CrossTop:            "\033[38;5;234m┬\033[0m",
+		FooterRenderer:      func(content string) string { return fmt.Sprintf("\033[1m%s\033[0m", content) },
+		HeaderRenderer:      func(content string) string { return fmt.Sprintf("\033[1;4m%s\033[0m", content) },
+		InnerHorizontal:     "\033[38;5;234m─\033[0m",
+		InnerVertical:       "\033[38;5;234m│\033[0m",
+		Left:                "\033[38;5;234m│\033[0m",
+		LeftBottom:          "\033[38;5;234m└\033[0m",
+		LeftTop:             "\033[38;5;234m┌\033[0m",
+		Prefix:              " ",
+		Right:               "\033[38;5;234m│\033[0m",
+		RightBottom:         "\033[38;5;234m┘\033[0m",
+		RightTop:            "\033[38;5;234m┐\033[0m",
+		SeparatorCross:      "\033[38;5;234m╪\033[0m",
+		SeparatorHorizontal: "\033[38;5;234m═\033[0m",
+		SeparatorLeft:       "\033[38;5;234m╞\033[0m",
+		SeparatorRight:      "\033[38;5;234m╡\033[0m",
+		Suffix:              " ",
+		Top:                 "\033[38;5;234m─\033[0m",
 	}
 	OpenTableStyle = &TableStyle{
-		Bottom:          "",
-		ContentRenderer: func(content string) string { return content },
-		CrossBottom:     "┴",
-		CrossInner:      "┼",
-		CrossLeft:       "",
-		CrossRight:      "",
-		CrossTop:        "┬",
-		HeaderRenderer:  func(content string) string { return fmt.Sprintf("\033[1m%s\033[0m", content) },
-		InnerHorizontal: "─",
-		InnerVertical:   "│",
-		Left:            "",
-		LeftBottom:      "",
-		LeftTop:         "",
-		Prefix:          " ",
-		Right:           "",
-		RightBottom:     "",
-		RightTop:        "",
-		Suffix:          " ",
-		Top:             "",
+		Bottom:              "",
+		ContentRenderer:     func(content string) string { return content },
+		CrossBottom:         "┴",
+		CrossInner:          "┼",
+		CrossLeft:           "",
+		CrossRight:          "",
+		CrossTop:            "┬",
+		FooterRenderer:      func(content string) string { return fmt.Sprintf("\033[1m%s\033[0m", content) },
+		HeaderRenderer:      func(content string) string { return fmt.Sprintf("\033[1m%s\033[0m", content) },
+		InnerHorizontal:     "─",
+		InnerVertical:       "│",
+		Left:                "",
+		LeftBottom:          "",
+		LeftTop:             "",
+		Prefix:              " ",
+		Right:               "",
+		RightBottom:         "",
+		RightTop:            "",
+		SeparatorCross:      "╪",
+		SeparatorHorizontal: "═",
+		SeparatorLeft:       "",
+		SeparatorRight:      "",
+		Suffix:              " ",
+		Top:                 "",
 	}
 	OpenTableStyleLight = &TableStyle{
-		Bottom:          "",
-		ContentRenderer: func(content string) string { return content },
-		CrossBottom:     "\033[38;5;234m┴\033[0m",
-		CrossInner:      "\033[38;5;234m┼\033[0m",
-		CrossLeft:       "",
-		CrossRight:      "",
-		CrossTop:        "\033[38;5;234m┬\033[0m",
-		HeaderRenderer:  func(content string) string { return fmt.Sprintf("\033[1m%s\033[0m", content) },
-		InnerHorizontal: "\033[38;5;234m─\033[0m",
-		InnerVertical:   "\033[38;5;234m│\033[0m",
-		Left:            "",
-		LeftBottom:      "",
-		LeftTop:         "",
-		Prefix:          " ",
-		Right:           "",
-		RightBottom:     "",
-		RightTop:        "",
-		Suffix:          " ",
-		Top:             "",
+		Bottom:              "",
+		ContentRenderer:     func(content string) string { return content },
+		CrossBottom:         "\033[38;5;234m┴\033[0m",
+		CrossInner:          "\033[38;5;234m┼\033[0m",
+		CrossLeft:           "",
+		CrossRight:          "",
+		CrossTop:            "\033[38;5;234m┬\033[0m",
+		FooterRenderer:      func(content string) string { return fmt.Sprintf("\033[1m%s\033[0m", content) },
+		HeaderRenderer:      func(content string) string { return fmt.Sprintf("\033[1m%s\033[0m", content) },
+		InnerHorizontal:     "\033[38;5;234m─\033[0m",
+		InnerVertical:       "\033[38;5;234m│\033[0m",
+		Left:                "",
+		LeftBottom:          "",
+		LeftTop:             "",
+		Prefix:              " ",
+		Right:               "",
+		RightBottom:         "",
+		RightTop:            "",
+		SeparatorCross:      "\033[38;5;234m╪\033[0m",
+		SeparatorHorizontal: "\033[38;5;234m═\033[0m",
+		SeparatorLeft:       "",
+		SeparatorRight:      "",
+		Suffix:              " ",
+		Top:                 "",
 	}
 	DefaultTableStyle *TableStyle
 )
@@ -107,6 +127,7 @@ func CopyTableStyle(from *TableStyle) *TableStyle {
 	to.CrossRight = from.CrossRight
 	to.CrossTop = from.CrossTop
 	to.HeaderRenderer = from.HeaderRenderer
+	to.FooterRenderer = from.FooterRenderer
 	to.InnerHorizontal = from.InnerHorizontal
 	to.InnerVertical = from.InnerVertical
 	to.Left = from.Left
@@ -114,6 +135,10 @@ func CopyTableStyle(from *TableStyle) *TableStyle {
 	to.LeftTop = from.LeftTop
 	to.Prefix = from.Prefix
 	to.Right = from.Right
+	to.SeparatorCross = from.SeparatorCross
+	to.SeparatorHorizontal = from.SeparatorHorizontal
+	to.SeparatorLeft = from.SeparatorLeft
+	to.SeparatorRight = from.SeparatorRight
 	to.RightBottom = from.RightBottom
 	to.RightTop = from.RightTop
 	to.Suffix = from.Suffix
@@ -121,6 +146,22 @@ func CopyTableStyle(from *TableStyle) *TableStyle {
 	return to
 }
 
+// PlainTableStyle returns a copy of the given table style, without control
+// characters (colors) in the border characters
+func PlainTableStyle(from *TableStyle) *TableStyle {
+	to := CopyTableStyle(from)
+	for _, border := range []*string{
+		&to.Bottom, &to.CrossBottom, &to.CrossInner, &to.CrossLeft, &to.CrossRight,
+		&to.CrossTop, &to.InnerHorizontal, &to.InnerVertical, &to.Left,
+		&to.LeftBottom, &to.LeftTop, &to.Prefix, &to.Right, &to.RightBottom,
+		&to.RightTop, &to.SeparatorCross, &to.SeparatorHorizontal,
+		&to.SeparatorLeft, &to.SeparatorRight, &to.Suffix, &to.Top,
+	} {
+		*border = rxControlCharacters.ReplaceAllString(*border, "")
+	}
+	return to
+}
+
 // Waste returns the amount of "wasted" characters (table render characters
 // + prefix and suffix whitespaces) for given amount of columns
 func (this *TableStyle) Waste(colCount int) int {
@@ -144,14 +185,22 @@ func (this *TableStyle) Render(table *Table, mw ...int) string {
 	if len(mw) > 0 {
 		maxWidth = mw[0]
 	}
+	table = this.visibleColumns(table, maxWidth)
 	colWidths := this.CalculateColWidths(table, maxWidth)
-	out := this.renderTopRow(colWidths)
+	out := this.renderTopRow(table.Headers, colWidths)
 	out += this.renderHeaderRow(table.Headers, colWidths)
-	for _, row := range table.Rows {
-		out += this.renderDataRow(row, colWidths)
+	last := table.Headers
+	for idx, row := range table.Rows {
+		this.setContentRenderers(table, idx, row)
+		out += this.renderDataRow(last, row, colWidths)
+		last = row
+	}
+	if table.Footer != nil {
+		out += this.renderFooterRow(last, table.Footer, colWidths)
+		last = table.Footer
 	}
 	//out += "--"
-	out += this.renderBottomRow(colWidths)
+	out += this.renderBottomRow(last, colWidths)
 	//fmt.Printf("\n+ RENDER END\n")
 	return strings.TrimRight(out, "\n") + "\n"
 }
@@ -159,7 +208,7 @@ func (this *TableStyle) Render(table *Table, mw ...int) string {
 // CalculateColWidths returns the widths of the cols of the table, for given max width
 func (this *TableStyle) CalculateColWidths(table *Table, totalTableWidth int) []int {
 	if totalTableWidth == 0 {
-		totalTableWidth = TermWidthCurrent
+		totalTableWidth = termWidthCurrent()
 	}
 	waste := this.Waste(table.colAmount)
 	//fmt.Printf("\n+ CALC COL WIDTHS (MAX = %d, WASTE = %d)\n", totalTableWidth, waste)
@@ -168,62 +217,218 @@ func (this *TableStyle) CalculateColWidths(table *Table, totalTableWidth int) []
 	} else {
 		totalTableWidth -= waste
 	}
+	this.prepareDecimals(table)
 	colWidths := make([]int, table.colAmount)
 	sumColWidth := 0
 	table.Headers.SetRenderer(this.HeaderRenderer)
-	rows := make([]*TableRow, table.rowAmount+1)
+	rows := make([]*TableRow, len(table.Rows)+1)
 	rows[0] = table.Headers
 	for idx, row := range table.Rows {
-		row.SetRenderer(this.ContentRenderer)
+		this.setContentRenderers(table, idx, row)
 		rows[idx+1] = row
 	}
+	if table.Footer != nil {
+		table.Footer.SetRenderer(this.footerRenderer())
+		rows = append(rows, table.Footer)
+	}
 	for _, row := range rows {
 		widths := row.CalculateWidths(totalTableWidth)
 		//fmt.Printf("  ## ROW %d -> %v\n", idx, widths)
 		for idx, wd := range widths {
-			if wd > colWidths[idx] {
+			if row.ColSpan(idx) != 1 {
+				continue
+			} else if wd > colWidths[idx] {
 				sumColWidth -= colWidths[idx]
 				colWidths[idx] = wd
 				sumColWidth += wd
 			}
 		}
 	}
-	if sumColWidth == 0 {
-		sumColWidth = 1
-	}
 	if totalTableWidth > 0 {
-		factor := float64(totalTableWidth) / float64(sumColWidth)
-		usedWidth := 0
-		lastWidthIdx := len(colWidths) - 1
+		colWidths = this.distributeColWidths(table, colWidths, totalTableWidth)
+	} else {
 		for idx, width := range colWidths {
-			colWidths[idx] = int(float64(width) * factor)
-			usedWidth += colWidths[idx]
-			if idx == lastWidthIdx {
-				colWidths[idx] += totalTableWidth - usedWidth
-			}
+			colWidths[idx] = table.Column(idx).clamp(width)
 		}
 	}
 	//fmt.Printf("\n+ CALC COL WIDTHS DONE SUM=%d, (=%v)\n", sumColWidth, colWidths)
 	return colWidths
 }
 
-func (this *TableStyle) renderBorderRow(first, prefix, content, suffix, cross, last string, colWidths []int) string {
+// distributeColWidths stretches or shrinks the given col widths to the total
+// width, considering the fixed, min and max widths of the column settings.
+// Remaining width goes to the last column which can take it.
+func (this *TableStyle) distributeColWidths(table *Table, colWidths []int, totalTableWidth int) []int {
+	widths := make([]int, len(colWidths))
+	flexible := make([]int, 0)
+	sumFlexible := 0
+	remaining := totalTableWidth
+	for idx, width := range colWidths {
+		settings := table.Column(idx)
+		if settings != nil && settings.Width > 0 {
+			widths[idx] = settings.Width
+			remaining -= settings.Width
+		} else {
+			widths[idx] = width
+			flexible = append(flexible, idx)
+			sumFlexible += width
+		}
+	}
+	if sumFlexible == 0 {
+		sumFlexible = 1
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	factor := float64(remaining) / float64(sumFlexible)
+	usedWidth := 0
+	for _, idx := range flexible {
+		widths[idx] = table.Column(idx).clamp(int(float64(widths[idx]) * factor))
+		usedWidth += widths[idx]
+	}
+	leftover := remaining - usedWidth
+	for i := len(flexible) - 1; i >= 0 && leftover != 0; i-- {
+		idx := flexible[i]
+		width := table.Column(idx).clamp(widths[idx] + leftover)
+		if width < 0 {
+			width = 0
+		}
+		leftover -= width - widths[idx]
+		widths[idx] = width
+	}
+	return widths
+}
+
+// visibleColumns returns the table with all columns, which overflow with
+// `TABLE_OVERFLOW_HIDE` and would be too narrow for the given max width,
+// removed. Columns are removed from right to left, until all fit.
+func (this *TableStyle) visibleColumns(table *Table, maxWidth int) *Table {
+	for table.colAmount > 1 && table.hasHiddenOverflow() {
+		colWidths := this.CalculateColWidths(table, maxWidth)
+		hide := -1
+		for idx := table.colAmount - 1; idx >= 0; idx-- {
+			settings := table.Column(idx)
+			if settings == nil || settings.Overflow != TABLE_OVERFLOW_HIDE {
+				continue
+			}
+			minWidth := settings.MinWidth
+			if minWidth == 0 {
+				minWidth = table.Headers.Cols[idx].Width()
+			}
+			if colWidths[idx] < minWidth {
+				hide = idx
+				break
+			}
+		}
+		if hide == -1 {
+			break
+		}
+		indices := make([]int, 0, table.colAmount-1)
+		for idx := 0; idx < table.colAmount; idx++ {
+			if idx != hide {
+				indices = append(indices, idx)
+			}
+		}
+		table = table.projectColumns(indices)
+	}
+	return table
+}
+
+// prepareDecimals determines the max length of decimal places and the width
+// required to align all numbers of all columns with `TABLE_ALIGN_DECIMAL`
+func (this *TableStyle) prepareDecimals(table *Table) {
+	for idx := 0; idx < table.colAmount; idx++ {
+		settings := table.Column(idx)
+		if settings == nil {
+			continue
+		}
+		settings.decimals = 0
+		settings.numberWidth = 0
+		if settings.Align != TABLE_ALIGN_DECIMAL {
+			continue
+		}
+		integers := 0
+		for _, row := range table.Rows {
+			for _, line := range strings.Split(row.Cols[idx].Content(), "\n") {
+				if decimals, ok := tableDecimalsLength(line); ok {
+					if decimals > settings.decimals {
+						settings.decimals = decimals
+					}
+					if l := StringLength(strings.TrimSpace(line)) - decimals; l > integers {
+						integers = l
+					}
+				}
+			}
+		}
+		settings.numberWidth = integers + settings.decimals
+	}
+}
+
+func (this *TableStyle) renderBorderRow(first, content string, crosses []string, last string, colWidths []int) string {
 	row := first
-	lastColIdx := len(colWidths) - 1
 	for idx, colWidth := range colWidths {
-		row += strings.Repeat(prefix, StringLength(this.Prefix))
+		row += strings.Repeat(content, StringLength(this.Prefix))
 		if cw := colWidth; cw > 0 {
 			row += strings.Repeat(content, cw)
 		}
-		row += strings.Repeat(suffix, StringLength(this.Suffix))
-		if idx < lastColIdx {
-			row += cross
+		row += strings.Repeat(content, StringLength(this.Suffix))
+		if idx < len(crosses) {
+			row += crosses[idx]
 		}
 	}
 	row += last
 	return row
 }
 
+// borderCrosses returns the characters between the cols of a border row,
+// depending on whether the row above (`up`), the row below (`down`), both
+// (`cross`) or none (`none`) have a vertical line at this position. Rows can
+// be nil.
+func (this *TableStyle) borderCrosses(above, below *TableRow, colAmount int, cross, up, down, none string) []string {
+	if colAmount < 1 {
+		return []string{}
+	}
+	crosses := make([]string, colAmount-1)
+	for idx, _ := range crosses {
+		hasAbove := above != nil && above.hasBoundary(idx)
+		hasBelow := below != nil && below.hasBoundary(idx)
+		switch {
+		case hasAbove && hasBelow:
+			crosses[idx] = cross
+		case hasAbove:
+			crosses[idx] = up
+		case hasBelow:
+			crosses[idx] = down
+		default:
+			crosses[idx] = strings.Repeat(none, StringLength(this.InnerVertical))
+		}
+	}
+	return crosses
+}
+
+// renderCells renders the contents of all cells of the row, with cols spanning
+// multiple columns merged, and returns them with their widths
+func (this *TableStyle) renderCells(row *TableRow, colWidths []int) (rendered []string, widths []int) {
+	spacing := StringLength(this.Suffix) + StringLength(this.InnerVertical) + StringLength(this.Prefix)
+	rendered = make([]string, 0, len(row.Cols))
+	widths = make([]int, 0, len(row.Cols))
+	for idx, col := range row.Cols {
+		span := row.ColSpan(idx)
+		if span == 0 {
+			continue
+		}
+		width := colWidths[idx]
+		for i := 1; i < span; i++ {
+			width += spacing + colWidths[idx+i]
+		}
+		content, _, _ := col.RenderWithSettings(width, row.column(idx))
+		rendered = append(rendered, content)
+		widths = append(widths, width)
+	}
+	return
+}
+
 func (this *TableStyle) renderContentRow(first, cross, last string, colContents []string, colWidths []int) string {
 
 	// transform (i in slice[string] to (i, j in slice[string][string]) in which each row i has the
@@ -272,42 +477,85 @@ func (this *TableStyle) renderContentRow(first, cross, last string, colContents
 	return out
 }
 
-func (this *TableStyle) renderTopRow(colWidths []int) string {
+func (this *TableStyle) renderTopRow(row *TableRow, colWidths []int) string {
 	if this.Top != "" {
-		return this.renderBorderRow(this.LeftTop, this.Top, this.Top, this.Top, this.CrossTop, this.RightTop, colWidths) + "\n"
+		crosses := this.borderCrosses(nil, row, len(colWidths), this.CrossTop, this.CrossTop, this.CrossTop, this.Top)
+		return this.renderBorderRow(this.LeftTop, this.Top, crosses, this.RightTop, colWidths) + "\n"
 	}
 	return ""
 }
 
 func (this *TableStyle) renderHeaderRow(row *TableRow, colWidths []int) string {
 	row.SetRenderer(this.HeaderRenderer)
-	rendered, _ := row.RenderWithWidths(colWidths)
-	/*for idx, text := range rendered {
-		rendered[idx] = this.HeaderRenderer(text)
-	}*/
-	return this.renderContentRow(this.Left, this.InnerVertical, this.Right, rendered, colWidths)
+	rendered, widths := this.renderCells(row, colWidths)
+	return this.renderContentRow(this.Left, this.InnerVertical, this.Right, rendered, widths)
 }
 
-func (this *TableStyle) renderDataRow(row *TableRow, colWidths []int) string {
-	row.SetRenderer(this.ContentRenderer)
-	rendered, _ := row.RenderWithWidths(colWidths)
-	out := ""
-	out += this.renderBorderRow(this.CrossLeft, this.InnerHorizontal, this.InnerHorizontal, this.InnerHorizontal, this.CrossInner,
-		this.CrossRight, colWidths)
-	/*for idx, text := range rendered {
-		rendered[idx] = this.ContentRenderer(text)
-	}*/
-	out += "\n" + this.renderContentRow(this.Left, this.InnerVertical, this.Right, rendered, colWidths)
+func (this *TableStyle) renderDataRow(above, row *TableRow, colWidths []int) string {
+	rendered, widths := this.renderCells(row, colWidths)
+	out := this.renderSeparatorRow(above, row, colWidths, row.Separator)
+	out += "\n" + this.renderContentRow(this.Left, this.InnerVertical, this.Right, rendered, widths)
 	return out
 }
 
-func (this *TableStyle) renderBottomRow(colWidths []int) string {
+func (this *TableStyle) renderFooterRow(above, row *TableRow, colWidths []int) string {
+	row.SetRenderer(this.footerRenderer())
+	rendered, widths := this.renderCells(row, colWidths)
+	out := this.renderSeparatorRow(above, row, colWidths, true)
+	out += "\n" + this.renderContentRow(this.Left, this.InnerVertical, this.Right, rendered, widths)
+	return out
+}
+
+// renderSeparatorRow renders the line between two rows, which is either an
+// inner line or a line between groups of rows
+func (this *TableStyle) renderSeparatorRow(above, below *TableRow, colWidths []int, group bool) string {
+	if group && this.SeparatorHorizontal != "" {
+		crosses := this.borderCrosses(above, below, len(colWidths), this.SeparatorCross, this.SeparatorCross, this.SeparatorCross, this.SeparatorHorizontal)
+		return this.renderBorderRow(this.SeparatorLeft, this.SeparatorHorizontal, crosses, this.SeparatorRight, colWidths)
+	}
+	crosses := this.borderCrosses(above, below, len(colWidths), this.CrossInner, this.CrossBottom, this.CrossTop, this.InnerHorizontal)
+	return this.renderBorderRow(this.CrossLeft, this.InnerHorizontal, crosses, this.CrossRight, colWidths)
+}
+
+func (this *TableStyle) renderBottomRow(row *TableRow, colWidths []int) string {
 	if this.Bottom != "" {
-		return this.renderBorderRow(this.LeftBottom, this.Bottom, this.Bottom, this.Bottom, this.CrossBottom, this.RightBottom, colWidths)
+		crosses := this.borderCrosses(row, nil, len(colWidths), this.CrossBottom, this.CrossBottom, this.CrossBottom, this.Bottom)
+		return this.renderBorderRow(this.LeftBottom, this.Bottom, crosses, this.RightBottom, colWidths)
 	}
 	return ""
 }
 
+// setContentRenderers sets the renderers of all cols of the data row with the
+// given index, which apply the row, column and cell styles of the table
+func (this *TableStyle) setContentRenderers(table *Table, rowIdx int, row *TableRow) {
+	if !table.hasCellStyles() {
+		row.SetRenderer(this.ContentRenderer)
+		return
+	}
+	for colIdx, col := range row.Cols {
+		col.SetRenderer(this.styledContentRenderer(table, rowIdx, colIdx))
+	}
+}
+
+func (this *TableStyle) styledContentRenderer(table *Table, rowIdx, colIdx int) func(string) string {
+	return func(content string) string {
+		content = table.styleContent(rowIdx, colIdx, content)
+		if this.ContentRenderer != nil {
+			content = this.ContentRenderer(content)
+		}
+		return strings.Join(SplitFormattedString(content), "\n")
+	}
+}
+
+// footerRenderer returns the renderer of the footer, which defaults to the
+// content renderer
+func (this *TableStyle) footerRenderer() func(content string) string {
+	if this.FooterRenderer != nil {
+		return this.FooterRenderer
+	}
+	return this.ContentRenderer
+}
+
 func init() {
 	DefaultTableStyle = CopyTableStyle(ClosedTableStyle)
 }
@@ -5,6 +5,7 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 	"io/ioutil"
 	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -135,3 +136,121 @@ func TestTableStyle(t *testing.T) {
 		}
 	})
 }
+
+func TestTableStyleGroups(t *testing.T) {
+	Convey("Render footer, separators and spanning cols", t, func() {
+		style := CopyTableStyle(ClosedTableStyle)
+		style.HeaderRenderer = func(content string) string { return content }
+		style.FooterRenderer = nil
+		table := NewTable([]string{"Item", "Qty", "Cost"}, style)
+		table.AddRow([]string{"Compute", "", ""})
+		table.Rows[0].SetColSpan(0, 3)
+		table.AddRows([][]string{{"vm-1", "2", "10.00"}, {"vm-2", "1", "5.50"}})
+		table.AddSeparator().AddRow([]string{"Storage", "", ""})
+		table.Rows[3].SetColSpan(0, 2)
+		table.AddRow([]string{"disk", "4", "2.00"})
+		So(table.SetFooter([]string{"Total", "17.50"}), ShouldNotBeNil)
+		So(table.SetFooter([]string{"Total", "", "17.50"}), ShouldBeNil)
+		table.Footer.SetColSpan(0, 2)
+
+		So(table.Render(30), ShouldEqual, strings.Join([]string{
+			"┌──────────┬──────┬─────────────┐",
+			"│ Item     │ Qty  │ Cost        │",
+			"├──────────┴──────┴─────────────┤",
+			"│ Compute                       │",
+			"├──────────┬──────┬─────────────┤",
+			"│ vm-1     │ 2    │ 10.00       │",
+			"├──────────┼──────┼─────────────┤",
+			"│ vm-2     │ 1    │ 5.50        │",
+			"╞══════════╪══════╪═════════════╡",
+			"│ Storage         │             │",
+			"├──────────┬──────┼─────────────┤",
+			"│ disk     │ 4    │ 2.00        │",
+			"╞══════════╪══════╪═════════════╡",
+			"│ Total           │ 17.50       │",
+			"└─────────────────┴─────────────┘",
+			"",
+		}, "\n"))
+
+		Convey("Spans and separators are kept when selecting columns", func() {
+			selected, err := table.SelectColumns(0, 1)
+			So(err, ShouldBeNil)
+			So(selected.Rows[3].Separator, ShouldBeTrue)
+			So(selected.Rows[0].ColSpan(0), ShouldEqual, 2)
+			So(selected.Footer.ColSpan(0), ShouldEqual, 2)
+
+			selected, err = table.SelectColumns(2, 0)
+			So(err, ShouldBeNil)
+			So(selected.Rows[0].ColSpan(1), ShouldEqual, 1)
+		})
+
+		Convey("Footer is included in formats", func() {
+			out, err := table.RenderAs("csv")
+			So(err, ShouldBeNil)
+			So(out, ShouldEndWith, "disk,4,2.00\nTotal,,17.50\n")
+		})
+	})
+}
+
+func TestTableStyleCellStyles(t *testing.T) {
+	Convey("Style rows, columns and cells", t, func() {
+		out := NewColorOutput(ioutil.Discard)
+		table := out.Table([]string{"Name", "Price", "Note"}, CopyTableStyle(OpenTableStyle))
+		table.AddRows([][]string{
+			{"foo", "1.5", "ok"},
+			{"bar", "10.25", "failed"},
+			{"baz", "100", "ok"},
+		})
+		table.SetRowStyles("", "<debug>")
+		table.Column(1).SetStyle("<info>")
+		table.SetCellStyle(func(row, col int, value string) string {
+			if col == 2 && value == "failed" {
+				return "<error>" + value + "<reset>"
+			}
+			return value
+		})
+
+		lines := strings.Split(strings.TrimRight(table.Render(40), "\n"), "\n")
+		for _, line := range lines {
+			So(StringLength(line), ShouldEqual, StringLength(lines[0]))
+		}
+		So(lines[2], ShouldStartWith, " foo ")
+		So(lines[2], ShouldContainSubstring, " \033[34m1.5\033[0m ")
+		So(lines[4], ShouldStartWith, " \033[30;1mbar\033[0m ")
+		So(lines[4], ShouldContainSubstring, " \033[30;1m\033[34m10.25\033[0m ")
+		So(lines[4], ShouldContainSubstring, " \033[30;1m\033[31;1mfailed\033[0m")
+		So(lines[6], ShouldStartWith, " baz ")
+
+		Convey("Column indices of the cell style are kept when selecting columns", func() {
+			selected, err := table.SelectColumns(2)
+			So(err, ShouldBeNil)
+			So(selected.Render(40), ShouldContainSubstring, "\033[31;1mfailed")
+		})
+	})
+}
+
+func TestTableStyleCellStylesMonochrome(t *testing.T) {
+	Convey("Styled rows are plain in monochrome output", t, func() {
+		out := NewMonochromeOutput(ioutil.Discard)
+		table := out.Table([]string{"Name", "Price"})
+		table.AddRows([][]string{{"foo", "1"}, {"bar", "2"}})
+		plain := table.Render(40)
+		table.SetRowStyles("<info>", "")
+		table.Column(1).SetStyle("<debug>")
+		rendered := table.Render(40)
+		So(rendered, ShouldNotContainSubstring, "\033")
+		So(rendered, ShouldEqual, plain)
+	})
+}
+
+func TestTableStyleWideRunes(t *testing.T) {
+	Convey("Tables align wide runes", t, func() {
+		table := NewTable([]string{"Name", "Value"})
+		table.AddRow([]string{"日本", "x"})
+		table.AddRow([]string{"abcd", "👍"})
+		lines := strings.Split(strings.TrimRight(table.Render(40), "\n"), "\n")
+		for _, line := range lines {
+			So(StringLength(line), ShouldEqual, StringLength(lines[0]))
+		}
+	})
+}
@@ -0,0 +1,272 @@
+package clif
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type (
+
+	// TaskFunc is the work of a task. It should return early, if the context
+	// is cancelled.
+	TaskFunc func(ctx context.Context, progress TaskProgress) error
+
+	// TaskProgress reports the progress of a running task
+	TaskProgress interface {
+
+		// Bar returns the progress bar of the task, or nil if the task has no
+		// size and is rendered as spinner
+		Bar() ProgressBar
+
+		// Increase adds given amount to the progress of the task
+		Increase(amount int64)
+
+		// SetMessage sets a status message, eg "uploading foo.tar"
+		SetMessage(message string)
+	}
+
+	// TaskResult is the outcome of a task
+	TaskResult struct {
+
+		// Name is the name of the task
+		Name string
+
+		// Err is the error returned by the task, if any
+		Err error
+
+		// Cancelled is true if the task returned the error of its cancelled
+		// (or timed out) context, or an error wrapping it
+		Cancelled bool
+
+		// Duration is the run time of the task
+		Duration time.Duration
+	}
+
+	// TaskRunner runs tasks with limited concurrency and renders their
+	// progress in a progress bar pool. Tasks with size get a progress bar,
+	// tasks without size a spinner.
+	TaskRunner struct {
+		concurrency int
+		pool        ProgressBarPool
+		tasks       []*runnerTask
+		results     []*TaskResult
+		elapsed     time.Duration
+	}
+
+	runnerTask struct {
+		name string
+		size int64
+		run  TaskFunc
+	}
+
+	taskProgress struct {
+		name    string
+		bar     ProgressBar
+		spinner ProgressSpinner
+	}
+)
+
+var (
+	// TaskRunnerTableHeaders are the headers of the result table
+	TaskRunnerTableHeaders = []string{"Task", "Status", "Duration", "Error"}
+
+	// TaskRunnerRenderStatus renders the status of a task in the result table
+	TaskRunnerRenderStatus = func(result *TaskResult) string {
+		if result.Cancelled {
+			return "<warn>cancelled<reset>"
+		} else if result.Err != nil {
+			return "<error>failed<reset>"
+		}
+		return "<success>ok<reset>"
+	}
+)
+
+// NewTaskRunner constructs a new task runner, which renders progress in the
+// given pool and runs up to concurrency tasks at the same time
+func NewTaskRunner(pool ProgressBarPool, concurrency int) *TaskRunner {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &TaskRunner{
+		concurrency: concurrency,
+		pool:        pool,
+		tasks:       make([]*runnerTask, 0),
+	}
+}
+
+// Add is builder method and adds a task. Tasks with a size greater zero are
+// rendered as progress bar, others as spinner. Names must be unique.
+func (this *TaskRunner) Add(name string, size int64, run TaskFunc) *TaskRunner {
+	this.tasks = append(this.tasks, &runnerTask{name, size, run})
+	return this
+}
+
+// Results returns the results of the last run, in the order the tasks were
+// added
+func (this *TaskRunner) Results() []*TaskResult {
+	return this.results
+}
+
+// Run runs all tasks and waits until they are finished. Tasks which are not
+// started when the context is cancelled are not run. Returns the results in
+// the order the tasks were added and an error, if any task failed.
+func (this *TaskRunner) Run(ctx context.Context) ([]*TaskResult, error) {
+	names := make(map[string]bool)
+	for _, task := range this.tasks {
+		if names[task.name] {
+			return nil, fmt.Errorf("Task with name \"%s\" is added multiple times", task.name)
+		}
+		names[task.name] = true
+	}
+
+	started := time.Now()
+	results := make([]*TaskResult, len(this.tasks))
+	slots := make(chan bool, this.concurrency)
+	wg := new(sync.WaitGroup)
+	this.pool.Start()
+	for idx, task := range this.tasks {
+		results[idx] = &TaskResult{Name: task.name}
+		if ctx.Err() == nil {
+			select {
+			case slots <- true:
+			case <-ctx.Done():
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			results[idx].Err = err
+			results[idx].Cancelled = true
+			continue
+		}
+		wg.Add(1)
+		go func(task *runnerTask, result *TaskResult) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			this.run(ctx, task, result)
+		}(task, results[idx])
+	}
+	wg.Wait()
+	<-this.pool.Finish()
+
+	// remove bars and spinners, so that tasks can be run again
+	for _, task := range this.tasks {
+		this.pool.Remove(task.name)
+	}
+
+	this.results = results
+	this.elapsed = time.Now().Sub(started)
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return results, fmt.Errorf("%d of %d tasks failed", failed, len(results))
+	}
+	return results, nil
+}
+
+// Table returns a table of the results of the last run, with status, duration
+// and error of each task
+func (this *TaskRunner) Table(out Output) *Table {
+	table := out.Table(TaskRunnerTableHeaders)
+	table.Column(2).SetAlign(TABLE_ALIGN_RIGHT)
+	ok := 0
+	for _, result := range this.results {
+		errMsg := ""
+		if result.Err != nil {
+			errMsg = out.Escape(result.Err.Error())
+		} else {
+			ok++
+		}
+		table.AddRow([]string{
+			out.Escape(result.Name),
+			TaskRunnerRenderStatus(result),
+			RenderFixedSizeDuration(result.Duration),
+			errMsg,
+		})
+	}
+	table.SetFooter([]string{
+		"",
+		fmt.Sprintf("%d/%d ok", ok, len(this.results)),
+		RenderFixedSizeDuration(this.elapsed),
+		"",
+	})
+	return table
+}
+
+func (this *TaskRunner) run(ctx context.Context, task *runnerTask, result *TaskResult) {
+	progress := &taskProgress{name: task.name}
+	var err error
+	if task.size > 0 {
+		if progress.bar, err = this.pool.Init(task.name, 1); err == nil {
+			progress.bar.SetSize64(task.size).SetLabel(task.name)
+		}
+	} else if progress.spinner, err = this.pool.InitSpinner(task.name); err == nil {
+		progress.spinner.SetMessage(task.name)
+	}
+	if err != nil {
+		result.Err = err
+		return
+	}
+
+	started := time.Now()
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("Task \"%s\" panicked: %v", task.name, r)
+			}
+		}()
+		err = task.run(ctx, progress)
+	}()
+	result.Duration = time.Now().Sub(started)
+	result.Err = err
+	result.Cancelled = errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+
+	if err != nil {
+		progress.fail()
+	} else {
+		progress.finish()
+	}
+}
+
+func (this *taskProgress) Bar() ProgressBar {
+	return this.bar
+}
+
+func (this *taskProgress) Increase(amount int64) {
+	if this.bar != nil {
+		this.bar.Increase64(amount)
+	}
+}
+
+func (this *taskProgress) SetMessage(message string) {
+	if this.bar != nil {
+		this.bar.SetMessage(message)
+	} else if message == "" {
+		this.spinner.SetMessage(this.name)
+	} else {
+		this.spinner.SetMessage(this.name + ": " + message)
+	}
+}
+
+func (this *taskProgress) fail() {
+	if this.bar != nil {
+		this.bar.Fail()
+	} else {
+		this.spinner.Fail()
+	}
+}
+
+func (this *taskProgress) finish() {
+	if this.bar != nil {
+		this.bar.Finish()
+	} else {
+		this.spinner.Finish()
+	}
+}
@@ -0,0 +1,158 @@
+package clif
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func _testTaskRunner() *TaskRunner {
+	pool := NewProgressBarPool()
+	pool.Mode(PROGRESS_BAR_POOL_QUIET)
+	return NewTaskRunner(pool, 2)
+}
+
+func TestTaskRunner(t *testing.T) {
+	Convey("Run tasks", t, func() {
+		runner := _testTaskRunner()
+		mux := new(sync.Mutex)
+		running, maxRunning := 0, 0
+		bars := 0
+		for i := 0; i < 5; i++ {
+			runner.Add(fmt.Sprintf("task-%d", i), 10, func(ctx context.Context, progress TaskProgress) error {
+				mux.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				mux.Unlock()
+				defer func() {
+					mux.Lock()
+					running--
+					mux.Unlock()
+				}()
+				if progress.Bar() != nil {
+					mux.Lock()
+					bars++
+					mux.Unlock()
+				}
+				progress.Increase(5)
+				progress.SetMessage("half way")
+				return nil
+			})
+		}
+		runner.Add("fail", 0, func(ctx context.Context, progress TaskProgress) error {
+			if progress.Bar() != nil {
+				return fmt.Errorf("unexpected bar")
+			}
+			progress.SetMessage("failing")
+			return fmt.Errorf("<b>oops</b>")
+		})
+		runner.Add("panic", 0, func(ctx context.Context, progress TaskProgress) error {
+			panic("boom")
+		})
+
+		results, err := runner.Run(context.Background())
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "2 of 7 tasks failed")
+		So(len(results), ShouldEqual, 7)
+		So(maxRunning, ShouldBeLessThanOrEqualTo, 2)
+		So(bars, ShouldEqual, 5)
+		So(results[0].Name, ShouldEqual, "task-0")
+		So(results[0].Err, ShouldBeNil)
+		So(results[5].Err.Error(), ShouldEqual, "<b>oops</b>")
+		So(results[6].Err.Error(), ShouldContainSubstring, "boom")
+
+		Convey("Render result table", func() {
+			buf := bytes.NewBuffer(nil)
+			rendered := runner.Table(NewMonochromeOutput(buf)).Render(80)
+			So(rendered, ShouldContainSubstring, "task-0")
+			So(rendered, ShouldContainSubstring, "<b>oops</b>")
+			So(rendered, ShouldContainSubstring, "5/7 ok")
+			So(strings.Count(rendered, "failed"), ShouldEqual, 2)
+		})
+
+		Convey("Run again", func() {
+			_, err := runner.Run(context.Background())
+			So(err.Error(), ShouldEqual, "2 of 7 tasks failed")
+		})
+	})
+}
+
+func TestTaskRunnerLines(t *testing.T) {
+	Convey("Render progress of concurrent tasks as lines", t, func() {
+		buf := bytes.NewBuffer(nil)
+		pool := NewProgressBarPool()
+		pool.Mode(PROGRESS_BAR_POOL_LINES)
+		pool.Writer(buf)
+		pool.Interval(time.Millisecond)
+		runner := NewTaskRunner(pool, 3)
+		for i := 0; i < 3; i++ {
+			runner.Add(fmt.Sprintf("bar-%d", i), 4, func(ctx context.Context, progress TaskProgress) error {
+				for j := 0; j < 4; j++ {
+					progress.Increase(1)
+					progress.SetMessage(fmt.Sprintf("step %d", j))
+					time.Sleep(time.Millisecond * 2)
+				}
+				return nil
+			})
+			fail := i == 2
+			runner.Add(fmt.Sprintf("spinner-%d", i), 0, func(ctx context.Context, progress TaskProgress) error {
+				for j := 0; j < 4; j++ {
+					progress.SetMessage(fmt.Sprintf("step %d", j))
+					time.Sleep(time.Millisecond * 2)
+				}
+				if fail {
+					return fmt.Errorf("oops")
+				}
+				return nil
+			})
+		}
+		_, err := runner.Run(context.Background())
+		So(err, ShouldNotBeNil)
+
+		lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+		So(len(lines), ShouldBeGreaterThanOrEqualTo, 6)
+		final := strings.Join(lines[len(lines)-6:], "\n")
+		for i := 0; i < 3; i++ {
+			So(final, ShouldContainSubstring, fmt.Sprintf("bar-%d: 100%% (4/4)", i))
+		}
+		So(final, ShouldContainSubstring, "spinner-0: spinner-0: step 3 done")
+		So(final, ShouldContainSubstring, "spinner-2: spinner-2: step 3 failed")
+	})
+}
+
+func TestTaskRunnerCancel(t *testing.T) {
+	Convey("Cancel tasks", t, func() {
+		runner := NewTaskRunner(NewProgressBarPool(), 1)
+		runner.pool.Mode(PROGRESS_BAR_POOL_QUIET)
+		ctx, cancel := context.WithCancel(context.Background())
+		runner.Add("first", 0, func(ctx context.Context, progress TaskProgress) error {
+			cancel()
+			<-ctx.Done()
+			return fmt.Errorf("Stopped first: %w", ctx.Err())
+		})
+		runner.Add("second", 0, func(ctx context.Context, progress TaskProgress) error {
+			return nil
+		})
+		results, err := runner.Run(ctx)
+		So(err, ShouldNotBeNil)
+		So(results[0].Cancelled, ShouldBeTrue)
+		So(runner.Table(NewMonochromeOutput(nil)).Render(80), ShouldContainSubstring, "cancelled")
+		So(results[1].Cancelled, ShouldBeTrue)
+		So(results[1].Duration, ShouldEqual, 0)
+	})
+
+	Convey("Task names must be unique", t, func() {
+		runner := _testTaskRunner().
+			Add("foo", 0, nil).
+			Add("foo", 0, nil)
+		_, err := runner.Run(context.Background())
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -9,12 +9,14 @@ package clif
 
 import (
 	"os"
+	"sync"
 )
 
 const (
 	TERM_TIOCGWINSZ     = 0x5413
 	TERM_TIOCGWINSZ_OSX = 1074295912
 	TERM_DEFAULT_WIDTH  = 78
+	TERM_DEFAULT_HEIGHT = 24
 )
 
 var (
@@ -24,11 +26,42 @@ var (
 	TermWidthCall func() (int, error)
 
 	// TermWidthCurrent contains the current terminal width from the last
-	// call of `TerminalWidth()` (which is called in `init()`)
+	// call of `TerminalWidth()` (which is called in `init()`). It is updated
+	// on terminal resizes, so read it with `TermSizeCurrent()`.
 	TermWidthCurrent = TERM_DEFAULT_WIDTH
+
+	// TermHeightCall is the callback returning the terminal height
+	TermHeightCall func() (int, error)
+
+	// TermHeightCurrent contains the current terminal height from the last
+	// call of `TermHeight()` (which is called in `init()`). It is updated
+	// on terminal resizes, so read it with `TermSizeCurrent()`.
+	TermHeightCurrent = TERM_DEFAULT_HEIGHT
+
+	// TermIsTerminalCall is the callback returning whether a file descriptor
+	// is a terminal
+	TermIsTerminalCall func(fd uintptr) bool
+
+	// TermResizeAutoWatch controls whether `New()` starts watching for terminal
+	// resizes, if stdout is a terminal. Set to false before to disable.
+	TermResizeAutoWatch = true
+
+	// termWatchResize starts the platform specific watching of terminal resizes,
+	// which calls `termResized()` on each resize
+	termWatchResize func()
+
+	termResizeMux         = new(sync.Mutex)
+	termResizeOnce        = new(sync.Once)
+	termResizeSubscribers = make(map[int]TermResizeCallback)
+	termResizeNextId      = 0
 )
 
 type (
+
+	// TermResizeCallback is called with the new terminal width and height
+	// after the terminal was resized
+	TermResizeCallback func(width, height int)
+
 	termWindow struct {
 		Row    uint16
 		Col    uint16
@@ -41,3 +74,92 @@ type (
 func TermWidth() (int, error) {
 	return TermWidthCall()
 }
+
+// TermHeight returns the terminal height in amount of lines
+func TermHeight() (int, error) {
+	return TermHeightCall()
+}
+
+// TermIsTerminal returns whether the given file descriptor (eg `os.Stdout.Fd()`)
+// is a terminal
+func TermIsTerminal(fd uintptr) bool {
+	return TermIsTerminalCall(fd)
+}
+
+// TermSize returns the terminal width in amount of characters and the height
+// in amount of lines
+func TermSize() (w, h int, err error) {
+	if w, err = TermWidth(); err != nil {
+		return
+	}
+	h, err = TermHeight()
+	return
+}
+
+// TermSizeCurrent returns the current terminal width and height (see
+// `TermWidthCurrent` and `TermHeightCurrent`), synchronized with updates on
+// terminal resizes
+func TermSizeCurrent() (width, height int) {
+	termResizeMux.Lock()
+	defer termResizeMux.Unlock()
+	return TermWidthCurrent, TermHeightCurrent
+}
+
+func termWidthCurrent() int {
+	width, _ := TermSizeCurrent()
+	return width
+}
+
+func termHeightCurrent() int {
+	_, height := TermSizeCurrent()
+	return height
+}
+
+// TermWatchResize starts watching for terminal resizes (SIGWINCH), which update
+// `TermWidthCurrent` and `TermHeightCurrent`. It is called in `New()` (see
+// `TermResizeAutoWatch`) and on `TermOnResize()`. Multiple calls do not start
+// multiple watchers. Running progress bar pools re-flow on resize, tables and
+// layouts use the new size on their next render.
+func TermWatchResize() {
+	termResizeOnce.Do(func() {
+		if termWatchResize != nil {
+			termWatchResize()
+		}
+	})
+}
+
+// TermOnResize registers a callback, which is called whenever the terminal is
+// resized. Returns a function which removes the callback again.
+func TermOnResize(cb TermResizeCallback) func() {
+	TermWatchResize()
+	termResizeMux.Lock()
+	defer termResizeMux.Unlock()
+	id := termResizeNextId
+	termResizeNextId++
+	termResizeSubscribers[id] = cb
+	return func() {
+		termResizeMux.Lock()
+		defer termResizeMux.Unlock()
+		delete(termResizeSubscribers, id)
+	}
+}
+
+// termResized updates the current terminal size and notifies all subscribers
+func termResized() {
+	w, werr := TermWidth()
+	h, herr := TermHeight()
+	if werr != nil || herr != nil {
+		return
+	}
+	termResizeMux.Lock()
+	TermWidthCurrent = w
+	TermHeightCurrent = h
+	subscribers := make([]TermResizeCallback, 0, len(termResizeSubscribers))
+	for _, cb := range termResizeSubscribers {
+		subscribers = append(subscribers, cb)
+	}
+	termResizeMux.Unlock()
+	for _, cb := range subscribers {
+		cb(w, h)
+	}
+}
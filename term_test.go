@@ -0,0 +1,56 @@
+package clif
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestTermResize(t *testing.T) {
+	Convey("Notify subscribers on terminal resize", t, func() {
+		origWidthCall, origHeightCall := TermWidthCall, TermHeightCall
+		origWidth, origHeight := TermWidthCurrent, TermHeightCurrent
+		defer func() {
+			TermWidthCall, TermHeightCall = origWidthCall, origHeightCall
+			TermWidthCurrent, TermHeightCurrent = origWidth, origHeight
+		}()
+		TermWidthCall = func() (int, error) { return 123, nil }
+		TermHeightCall = func() (int, error) { return 45, nil }
+
+		w, h, err := TermSize()
+		So(err, ShouldBeNil)
+		So(w, ShouldEqual, 123)
+		So(h, ShouldEqual, 45)
+
+		calls := [][]int{}
+		unsubscribe := TermOnResize(func(width, height int) {
+			calls = append(calls, []int{width, height})
+		})
+		termResized()
+		So(calls, ShouldResemble, [][]int{{123, 45}})
+		w, h = TermSizeCurrent()
+		So(w, ShouldEqual, 123)
+		So(h, ShouldEqual, 45)
+
+		Convey("Unsubscribed callbacks are not called", func() {
+			unsubscribe()
+			termResized()
+			So(len(calls), ShouldEqual, 1)
+		})
+
+		Convey("Current size is read while resized", func() {
+			unsubscribe()
+			done := make(chan bool)
+			go func() {
+				for i := 0; i < 100; i++ {
+					termResized()
+				}
+				close(done)
+			}()
+			for i := 0; i < 100; i++ {
+				NewTable([]string{"foo"}).Render()
+			}
+			<-done
+			So(termWidthCurrent(), ShouldEqual, 123)
+		})
+	})
+}
@@ -4,9 +4,17 @@ package clif
 
 import (
 	"github.com/olekukonko/ts"
+	"syscall"
+	"time"
 )
 
+// TermResizePollInterval is the interval in which the terminal size is
+// checked for changes, since Windows does not support SIGWINCH
+var TermResizePollInterval = time.Millisecond * 500
+
 func init() {
+	PagerShell = []string{"cmd", "/C"}
+
 	TermWidthCall = func() (int, error) {
 		size, err := ts.GetSize()
 		if err != nil {
@@ -15,5 +23,31 @@ func init() {
 		return size.Col(), nil
 	}
 
+	TermHeightCall = func() (int, error) {
+		size, err := ts.GetSize()
+		if err != nil {
+			return TERM_DEFAULT_HEIGHT, err
+		}
+		return size.Row(), nil
+	}
+
+	TermIsTerminalCall = func(fd uintptr) bool {
+		var mode uint32
+		return syscall.GetConsoleMode(syscall.Handle(fd), &mode) == nil
+	}
+
+	termWatchResize = func() {
+		go func() {
+			w, h := TermSizeCurrent()
+			for range time.Tick(TermResizePollInterval) {
+				if size, err := ts.GetSize(); err == nil && (size.Col() != w || size.Row() != h) {
+					w, h = size.Col(), size.Row()
+					termResized()
+				}
+			}
+		}()
+	}
+
 	TermWidthCurrent, _ = TermWidthCall()
-}
\ No newline at end of file
+	TermHeightCurrent, _ = TermHeightCall()
+}
@@ -4,28 +4,61 @@ package clif
 
 import (
 	"os"
+	"os/signal"
 	"runtime"
 	"syscall"
 	"unsafe"
 )
 
+func termWinsize(fd uintptr) (*termWindow, error) {
+	w := new(termWindow)
+	tio := syscall.TIOCGWINSZ
+	if runtime.GOOS == "darwin" {
+		tio = TERM_TIOCGWINSZ_OSX
+	}
+	res, _, err := syscall.Syscall(sys_ioctl,
+		fd,
+		uintptr(tio),
+		uintptr(unsafe.Pointer(w)),
+	)
+	if err != 0 || int(res) == -1 {
+		return nil, os.NewSyscallError("GetWinsize", err)
+	}
+	return w, nil
+}
+
 func init() {
 	TermWidthCall = func() (int, error) {
-		w := new(termWindow)
-		tio := syscall.TIOCGWINSZ
-		if runtime.GOOS == "darwin" {
-			tio = TERM_TIOCGWINSZ_OSX
-		}
-		res, _, err := syscall.Syscall(sys_ioctl,
-			uintptr(syscall.Stdin),
-			uintptr(tio),
-			uintptr(unsafe.Pointer(w)),
-		)
-		if err != 0 || int(res) == -1 {
-			return TERM_DEFAULT_WIDTH, os.NewSyscallError("GetWinsize", err)
+		w, err := termWinsize(uintptr(syscall.Stdin))
+		if err != nil {
+			return TERM_DEFAULT_WIDTH, err
 		}
 		return int(w.Col) - 4, nil
 	}
 
+	TermHeightCall = func() (int, error) {
+		w, err := termWinsize(uintptr(syscall.Stdin))
+		if err != nil {
+			return TERM_DEFAULT_HEIGHT, err
+		}
+		return int(w.Row), nil
+	}
+
+	TermIsTerminalCall = func(fd uintptr) bool {
+		_, err := termWinsize(fd)
+		return err == nil
+	}
+
+	termWatchResize = func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGWINCH)
+		go func() {
+			for range sig {
+				termResized()
+			}
+		}()
+	}
+
 	TermWidthCurrent, _ = TermWidthCall()
+	TermHeightCurrent, _ = TermHeightCall()
 }
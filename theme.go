@@ -0,0 +1,291 @@
+package clif
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type (
+
+	// Theme bundles everything which determines the look of the output: the
+	// styles of the formatter tokens (eg `<error>`), the table style (including
+	// border colors) and the progress bar style.
+	Theme struct {
+
+		// Name is the unique name under which the theme is registered
+		Name string
+
+		// Styles maps formatter tokens to control characters. See `DefaultStyles`
+		Styles map[string]string
+
+		// TableStyle is used for all tables created by an output using this
+		// theme. If nil, the `DefaultTableStyle` is used
+		TableStyle *TableStyle
+
+		// ProgressBarStyle is used for all progress bars of an output using
+		// this theme. If nil, the pool keeps it's current style
+		ProgressBarStyle *ProgressBarStyle
+	}
+
+	// themeFile is the structure of JSON or YAML theme files.
+	themeFile struct {
+		Name        string            `json:"name" yaml:"name"`
+		Styles      map[string]string `json:"styles" yaml:"styles"`
+		Table       *themeFileTable   `json:"table" yaml:"table"`
+		ProgressBar *themeFileBar     `json:"progress_bar" yaml:"progress_bar"`
+	}
+
+	themeFileTable struct {
+		Style  string `json:"style" yaml:"style"`
+		Border string `json:"border" yaml:"border"`
+	}
+
+	themeFileBar struct {
+		Style       string `json:"style" yaml:"style"`
+		Progress    string `json:"progress" yaml:"progress"`
+		Rightmost   string `json:"rightmost" yaml:"rightmost"`
+		None        string `json:"none" yaml:"none"`
+		LeftBorder  string `json:"left_border" yaml:"left_border"`
+		RightBorder string `json:"right_border" yaml:"right_border"`
+	}
+)
+
+var (
+	// ThemeEnv is the name of the environment variable, which can contain the
+	// name of a registered theme or a path to a theme file. It is evaluated in
+	// `RunWith()`, before the command is parsed.
+	ThemeEnv = "CLIF_THEME"
+
+	// ThemeTableStyles are the table styles, which can be referenced by name
+	// in theme files
+	ThemeTableStyles = map[string]*TableStyle{
+		"closed": ClosedTableStyle,
+		"open":   OpenTableStyle,
+	}
+
+	// ThemeProgressBarStyles are the progress bar styles, which can be
+	// referenced by name in theme files
+	ThemeProgressBarStyles = map[string]*ProgressBarStyle{
+		"ascii": ProgressBarStyleAscii,
+		"utf8":  ProgressBarStyleUtf8,
+	}
+
+	themes    = make(map[string]*Theme)
+	themesMux = new(sync.Mutex)
+)
+
+// RegisterTheme adds a new theme with the given formatter styles to the theme
+// registry and returns it. An existing theme of same name is replaced. For each
+// style token `foo` a closing token `/foo` is added, if not existing. The
+// given styles are copied, so that they are not modified.
+func RegisterTheme(name string, from map[string]string) *Theme {
+	styles := make(map[string]string, len(from))
+	for k, v := range from {
+		styles[k] = v
+	}
+	if _, ok := styles["reset"]; !ok {
+		styles["reset"] = "\033[0m"
+	}
+	for k, _ := range styles {
+		if strings.Index(k, "/") != 0 {
+			if _, ok := styles["/"+k]; !ok {
+				styles["/"+k] = styles["reset"]
+			}
+		}
+	}
+	theme := &Theme{
+		Name:   name,
+		Styles: styles,
+	}
+	themesMux.Lock()
+	defer themesMux.Unlock()
+	themes[name] = theme
+	return theme
+}
+
+// GetTheme returns registered theme by name
+func GetTheme(name string) (*Theme, error) {
+	themesMux.Lock()
+	defer themesMux.Unlock()
+	if theme, ok := themes[name]; ok {
+		return theme, nil
+	}
+	return nil, fmt.Errorf("Theme \"%s\" is not registered", name)
+}
+
+// ThemeNames returns sorted list of names of all registered themes
+func ThemeNames() []string {
+	themesMux.Lock()
+	defer themesMux.Unlock()
+	names := make([]string, 0)
+	for name, _ := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadTheme reads a theme from a JSON or YAML (file extension `.yml` or `.yaml`)
+// file and registers it. If the file does not contain a name, the base file name
+// without extension is used.
+//
+// Control characters in styles can be written as `\033`, `\e` or `\x1b`, eg:
+//
+//  {
+//      "name": "mine",
+//      "styles": {"error": "\\033[31;1m", "info": "\\e[34m"},
+//      "table": {"style": "open", "border": "\\033[38;5;234m"},
+//      "progress_bar": {"style": "ascii", "progress": "#"}
+//  }
+func LoadTheme(path string) (*Theme, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file := new(themeFile)
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".yml" || ext == ".yaml" {
+		err = yaml.Unmarshal(raw, file)
+	} else {
+		err = json.Unmarshal(raw, file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse theme file %s: %s", path, err)
+	}
+
+	if file.Name == "" {
+		file.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	styles := make(map[string]string)
+	for k, v := range file.Styles {
+		styles[k] = unescapeThemeStyle(v)
+	}
+
+	var tableStyle *TableStyle
+	if file.Table != nil {
+		if file.Table.Style == "" {
+			file.Table.Style = "closed"
+		}
+		from, ok := ThemeTableStyles[file.Table.Style]
+		if !ok {
+			return nil, fmt.Errorf("Theme file %s: unknown table style \"%s\"", path, file.Table.Style)
+		}
+		tableStyle = ColorTableStyle(from, unescapeThemeStyle(file.Table.Border))
+	}
+
+	var barStyle *ProgressBarStyle
+	if file.ProgressBar != nil {
+		if file.ProgressBar.Style == "" {
+			file.ProgressBar.Style = "utf8"
+		}
+		from, ok := ThemeProgressBarStyles[file.ProgressBar.Style]
+		if !ok {
+			return nil, fmt.Errorf("Theme file %s: unknown progress bar style \"%s\"", path, file.ProgressBar.Style)
+		}
+		barStyle = CloneProgressBarStyle(from)
+		for _, c := range []struct {
+			from string
+			to   *rune
+		}{
+			{file.ProgressBar.Progress, &barStyle.Progress},
+			{file.ProgressBar.Rightmost, &barStyle.Rightmost},
+			{file.ProgressBar.None, &barStyle.None},
+			{file.ProgressBar.LeftBorder, &barStyle.LeftBorder},
+			{file.ProgressBar.RightBorder, &barStyle.RightBorder},
+		} {
+			if c.from != "" {
+				*c.to = []rune(c.from)[0]
+			}
+		}
+	}
+
+	return RegisterTheme(file.Name, styles).
+		SetTableStyle(tableStyle).
+		SetProgressBarStyle(barStyle), nil
+}
+
+// ResolveTheme returns the registered theme of the given name or, if a file
+// with the given name exists, loads the theme from that file.
+func ResolveTheme(nameOrPath string) (*Theme, error) {
+	if theme, err := GetTheme(nameOrPath); err == nil {
+		return theme, nil
+	} else if _, serr := os.Stat(nameOrPath); serr == nil {
+		return LoadTheme(nameOrPath)
+	} else {
+		return nil, err
+	}
+}
+
+// NewThemeOption returns the "--theme" option, which is added to the default
+// options of a cli in `New()`. It accepts the name of a registered theme or a
+// path to a theme file.
+func NewThemeOption(cli *Cli) *Option {
+	return NewOption("theme", "", "Name of output theme or path to theme file", "", false, false).
+		SetParse(func(name, value string) (string, error) {
+		theme, err := ResolveTheme(value)
+		if err != nil {
+			return "", err
+		}
+		cli.SetTheme(theme)
+		return value, nil
+	})
+}
+
+// SetTableStyle is builder method and sets the table style of the theme
+func (this *Theme) SetTableStyle(style *TableStyle) *Theme {
+	this.TableStyle = style
+	return this
+}
+
+// SetProgressBarStyle is builder method and sets the progress bar style of the theme
+func (this *Theme) SetProgressBarStyle(style *ProgressBarStyle) *Theme {
+	this.ProgressBarStyle = style
+	return this
+}
+
+// ColorTableStyle returns a copy of the given table style in which all border
+// characters are wrapped in the given color (control characters). An empty
+// color returns an unmodified copy.
+func ColorTableStyle(from *TableStyle, color string) *TableStyle {
+	to := CopyTableStyle(from)
+	if color == "" {
+		return to
+	}
+	for _, s := range []*string{
+		&to.Bottom, &to.CrossBottom, &to.CrossInner, &to.CrossLeft, &to.CrossRight,
+		&to.CrossTop, &to.InnerHorizontal, &to.InnerVertical, &to.Left, &to.LeftBottom,
+		&to.LeftTop, &to.Right, &to.RightBottom, &to.RightTop, &to.Top,
+		&to.SeparatorCross, &to.SeparatorHorizontal, &to.SeparatorLeft, &to.SeparatorRight,
+	} {
+		if *s != "" {
+			*s = color + *s + "\033[0m"
+		}
+	}
+	return to
+}
+
+func unescapeThemeStyle(s string) string {
+	for _, esc := range []string{`\033`, `\e`, `\x1b`, `\u001b`} {
+		s = strings.Replace(s, esc, "\033", -1)
+	}
+	return s
+}
+
+func init() {
+	RegisterTheme("default", DefaultStyles).
+		SetTableStyle(ClosedTableStyle).
+		SetProgressBarStyle(ProgressBarStyleUtf8)
+	RegisterTheme("sunburn", SunburnStyles).
+		SetTableStyle(ColorTableStyle(ClosedTableStyle, "\033[38;5;226m")).
+		SetProgressBarStyle(ProgressBarStyleUtf8)
+	RegisterTheme("winter", WinterStyles).
+		SetTableStyle(ColorTableStyle(ClosedTableStyle, "\033[38;5;27m")).
+		SetProgressBarStyle(ProgressBarStyleUtf8)
+}
@@ -0,0 +1,139 @@
+package clif
+
+import (
+	"bytes"
+	. "github.com/smartystreets/goconvey/convey"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestThemeRegistry(t *testing.T) {
+	Convey("Built-in themes are registered", t, func() {
+		So(ThemeNames(), ShouldContain, "default")
+		So(ThemeNames(), ShouldContain, "sunburn")
+		So(ThemeNames(), ShouldContain, "winter")
+
+		Convey("Registering a new theme", func() {
+			styles := map[string]string{
+				"error": "E>",
+			}
+			theme := RegisterTheme("test-theme", styles)
+			So(theme.Styles["/error"], ShouldEqual, "\033[0m")
+			So(theme.Styles["reset"], ShouldEqual, "\033[0m")
+			So(styles, ShouldResemble, map[string]string{"error": "E>"})
+
+			Convey("Theme styles do not alias the given styles", func() {
+				theme.Styles["error"] = "X>"
+				So(styles["error"], ShouldEqual, "E>")
+				builtin, _ := GetTheme("default")
+				builtin.Styles["info"] = "X>"
+				defer func() { builtin.Styles["info"] = DefaultStyles["info"] }()
+				So(DefaultStyles["info"], ShouldNotEqual, "X>")
+			})
+
+			found, err := GetTheme("test-theme")
+			So(err, ShouldBeNil)
+			So(found, ShouldEqual, theme)
+
+			_, err = GetTheme("not-there")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestThemeLoad(t *testing.T) {
+	Convey("Load theme from JSON file", t, func() {
+		theme, err := LoadTheme("fixtures/theme.json")
+		So(err, ShouldBeNil)
+		So(theme.Name, ShouldEqual, "json-theme")
+		So(theme.Styles["error"], ShouldEqual, "\033[31m")
+		So(theme.Styles["info"], ShouldEqual, "\033[34m")
+		So(theme.TableStyle.InnerVertical, ShouldEqual, "\033[38;5;234m│\033[0m")
+		So(theme.TableStyle.Left, ShouldEqual, "")
+		So(theme.ProgressBarStyle.Progress, ShouldEqual, '#')
+		So(theme.ProgressBarStyle.LeftBorder, ShouldEqual, '[')
+
+		found, err := GetTheme("json-theme")
+		So(err, ShouldBeNil)
+		So(found, ShouldEqual, theme)
+	})
+	Convey("Load theme from YAML file", t, func() {
+		theme, err := ResolveTheme("fixtures/theme.yml")
+		So(err, ShouldBeNil)
+		So(theme.Name, ShouldEqual, "theme")
+		So(theme.Styles["error"], ShouldEqual, "\033[31m")
+		So(theme.TableStyle.Left, ShouldEqual, "\033[38;5;234m│\033[0m")
+		So(theme.ProgressBarStyle, ShouldBeNil)
+	})
+	Convey("Load theme from missing file", t, func() {
+		_, err := ResolveTheme("fixtures/not-there.json")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestThemeApply(t *testing.T) {
+	RegisterTheme("test-apply", map[string]string{
+		"info": "I>",
+	}).SetTableStyle(ColorTableStyle(OpenTableStyle, "B>"))
+
+	Convey("Apply theme to output", t, func() {
+		buf := bytes.NewBuffer(nil)
+		theme, _ := GetTheme("test-apply")
+		out := NewOutput(buf, nil).SetTheme(theme)
+		So(out.Sprintf("<info>foo</info>"), ShouldEqual, "I>foo\033[0m")
+
+		table := out.Table([]string{"H1", "H2"})
+		table.AddRow([]string{"foo", "bar"})
+		So(table.Render(20), ShouldContainSubstring, "B>│\033[0m")
+	})
+
+	Convey("Monochrome output stays monochrome", t, func() {
+		buf := bytes.NewBuffer(nil)
+		theme, _ := GetTheme("test-apply")
+		out := NewMonochromeOutput(buf).SetTheme(theme)
+		So(out.Sprintf("<info>foo</info>"), ShouldEqual, "foo")
+
+		table := out.Table([]string{"H1", "H2"})
+		table.AddRow([]string{"foo", "bar"})
+		So(table.Render(20), ShouldContainSubstring, "B>│")
+		So(table.Render(20), ShouldNotContainSubstring, "\033")
+	})
+
+	Convey("Apply theme from environment and option", t, func() {
+		buf := bytes.NewBuffer(nil)
+		cli := New("foo", "1.0.0", "").
+			SetOutput(NewColorOutput(buf)).
+			New("bar", "", func(out Output) {
+			out.Printf("<info>bar</info>")
+		})
+
+		os.Setenv(ThemeEnv, "test-apply")
+		defer os.Setenv(ThemeEnv, "")
+
+		cli.RunWith([]string{"bar"})
+		So(buf.String(), ShouldEqual, "I>bar\033[0m")
+
+		Convey("Option overwrites environment", func() {
+			buf.Reset()
+			cli.RunWith([]string{"bar", "--theme", "default"})
+			So(strings.Contains(buf.String(), "I>"), ShouldBeFalse)
+			So(buf.String(), ShouldEqual, "\033[34mbar\033[0m")
+		})
+	})
+
+	Convey("Invalid theme in environment falls back to default", t, func() {
+		buf := bytes.NewBuffer(nil)
+		cli := New("foo", "1.0.0", "").
+			SetOutput(NewColorOutput(buf)).
+			New("bar", "", func(out Output) {
+			out.Printf("<info>bar</info>")
+		})
+
+		os.Setenv(ThemeEnv, "not-there")
+		defer os.Setenv(ThemeEnv, "")
+
+		cli.RunWith([]string{"bar"})
+		So(buf.String(), ShouldEqual, "\033[34mbar\033[0m")
+	})
+}
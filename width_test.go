@@ -0,0 +1,39 @@
+package clif
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestRuneWidth(t *testing.T) {
+	Convey("Display width of runes", t, func() {
+		So(RuneWidth('a'), ShouldEqual, 1)
+		So(RuneWidth('█'), ShouldEqual, 1)
+		So(RuneWidth('日'), ShouldEqual, 2)
+		So(RuneWidth('한'), ShouldEqual, 2)
+		So(RuneWidth('Ａ'), ShouldEqual, 2)
+		So(RuneWidth('👍'), ShouldEqual, 2)
+		So(RuneWidth('\u0301'), ShouldEqual, 0)
+		So(RuneWidth(RUNE_ZERO_WIDTH_JOINER), ShouldEqual, 0)
+		So(RuneWidth('\uFE0F'), ShouldEqual, 0)
+	})
+}
+
+func TestStringLengthWidth(t *testing.T) {
+	Convey("String length is display width", t, func() {
+		So(StringLength("日本語"), ShouldEqual, 6)
+		So(StringLength("e\u0301"), ShouldEqual, 1)
+		So(StringLength("👨\u200D👩\u200D👧"), ShouldEqual, 2)
+		So(StringLength("\033[1m漢字\033[0m a"), ShouldEqual, 6)
+	})
+
+	Convey("Truncate to display width", t, func() {
+		So(TruncateString("日本語のテキスト", 7, "…"), ShouldEqual, "日本語…")
+		So(TruncateString("日本語のテキスト", 8, "…"), ShouldEqual, "日本語…")
+		So(TruncateString("👨\u200D👩\u200D👧👨\u200D👩\u200D👧👨\u200D👩\u200D👧", 5, "…"), ShouldEqual, "👨\u200D👩\u200D👧👨\u200D👩\u200D👧…")
+		So(TruncateString("\033[1m日本語\033[0m", 5, "…"), ShouldEqual, "\033[1m日本…\033[0m")
+		So(TruncateString("foo bar", 3, "日本"), ShouldEqual, "日")
+		So(TruncateString("foo", 0, "…"), ShouldEqual, "")
+		So(TruncateString("foo", -1, "…"), ShouldEqual, "")
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 	"unicode"
 )
@@ -32,6 +33,11 @@ type (
 		// Limit is the max length per wrapped line
 		Limit uint
 
+		// Styles are the formatter styles, of which the tokens are considered
+		// by `WrapTokens()`, eg the styles of the active theme. Defaults to
+		// `DefaultStyles`.
+		Styles map[string]string
+
 		// TrimMode defines whether/how wrapped lines are trimmed or not
 		TrimMode WrapTrimMode
 
@@ -76,7 +82,8 @@ func Wrap(s string, limit uint) string {
 	return NewWrapper(limit).Wrap(s)
 }
 
-// WrapString wraps the given string within lim width in characters.
+// WrapString wraps the given string within lim width in characters. Widths
+// are display widths, so wide runes (eg CJK or emoji) count double.
 // Code is partially stolen from https://raw.githubusercontent.com/mitchellh/go-wordwrap/master/wordwrap.go
 func (this *Wrapper) Wrap(s string) string {
 	lines := []string{""}
@@ -90,6 +97,30 @@ func (this *Wrapper) Wrap(s string) string {
 	controlCharNoneEndBuf := bytes.NewBuffer(nil)
 	var lastChar rune
 
+	// control chars within a word (eg "(\033[1mfoo") stay with the word, so
+	// their state applies only once the word is added to a line
+	controlCharInWord := false
+	wordControlChars := []string{}
+	wordControlCharsEnded := []bool{}
+	addControlChars := func(s string) {
+		if controlCharInWord {
+			wordBuf += s
+		} else {
+			lines[curLineNum] += s
+		}
+	}
+	commitWordControlChars := func() {
+		for idx, ctrl := range wordControlChars {
+			if wordControlCharsEnded[idx] {
+				controlCharNoneEndBuf.Reset()
+			} else {
+				controlCharNoneEndBuf.WriteString(ctrl)
+			}
+		}
+		wordControlChars = []string{}
+		wordControlCharsEnded = []bool{}
+	}
+
 	rxReplaceLeftBeforeCtrlChars := regexp.MustCompile(`^\s+\033`)
 	rxReplaceRightBeforeCtrlChars := regexp.MustCompile(`\s+(\033\[[0-9]+(?:;[0-9]+)*m)$`)
 	trimCurrent := func() {
@@ -144,21 +175,13 @@ func (this *Wrapper) Wrap(s string) string {
 	for _, char := range s {
 		if IsControlCharStart(byte(char)) {
 			_wrapDebug(">> INIT CTRL CHARS\n")
-			if wordBufLen > 0 {
-				_wrapDebug("  >> PREPEND WORD BUF \"%s\"\n", _stringRenderDump(wordBuf))
-				curLineLen += wordBufLen
-				lines[curLineNum] += wordBuf
-				wordBufLen = 0
-				wordBuf = ""
-			} else {
-				_wrapDebug("  >> NO WORD BUF\n")
-			}
+			controlCharInWord = wordBufLen > 0
 			controlCharBuf.WriteRune(char)
 			controlCharSeq = 1
 		} else if controlCharSeq == 1 { // expect "["
 			if char != 91 { // abort .. not "["
 				_wrapDebug(">> ABORT CTRL CHARS\n")
-				lines[curLineNum] += controlCharBuf.String()
+				addControlChars(controlCharBuf.String())
 				controlCharBuf.Reset()
 				controlCharSeq = 0
 			} else {
@@ -174,7 +197,7 @@ func (this *Wrapper) Wrap(s string) string {
 				controlCharSeq = 3
 			} else { // abort, "not valid char
 				_wrapDebug(">> ABORT CTRL CHARS 2(%c)\n", char)
-				lines[curLineNum] += controlCharBuf.String()
+				addControlChars(controlCharBuf.String())
 				controlCharBuf.Reset()
 				controlCharSeq = 0
 			}
@@ -193,8 +216,11 @@ func (this *Wrapper) Wrap(s string) string {
 				controlCharBuf.WriteRune(char)
 				controlCharSeq = 0
 				_wrapDebug(">> ADD CTRL CHARS: %s\n", _stringRenderDump(controlCharBuf.String()))
-				lines[curLineNum] += controlCharBuf.String()
-				if ctrlCharsEnded() {
+				addControlChars(controlCharBuf.String())
+				if controlCharInWord {
+					wordControlChars = append(wordControlChars, controlCharBuf.String())
+					wordControlCharsEnded = append(wordControlCharsEnded, ctrlCharsEnded())
+				} else if ctrlCharsEnded() {
 					controlCharNoneEndBuf.Reset()
 				} else {
 					controlCharNoneEndBuf.WriteString(controlCharBuf.String())
@@ -209,13 +235,14 @@ func (this *Wrapper) Wrap(s string) string {
 				controlCharSeq = 0
 			} else { // abort, "not valid char
 				_wrapDebug(">> ABORT CTRL CHARS 3\n")
-				lines[curLineNum] += controlCharBuf.String()
+				addControlChars(controlCharBuf.String())
 				controlCharBuf.Reset()
 				controlCharSeq = 0
 			}
 		} else {
 			if char == '\n' {
 				_wrapDebug(">> ADD BREAK\n")
+				commitWordControlChars()
 				finishLine(wordBuf)
 				wordBuf = ""
 				wordBufLen = 0
@@ -223,10 +250,11 @@ func (this *Wrapper) Wrap(s string) string {
 				_wrapDebug(">> ADD SPACE\n")
 				if wordBufLen > 0 || this.WhitespaceMode == WRAP_WHITESPACE_KEEP {
 					_wrapDebug(" >> SPACE WITH WORD OR KEEP\n")
+					commitWordControlChars()
 					lines[curLineNum] += wordBuf
 					curLineLen += wordBufLen
 					lines[curLineNum] += string(char)
-					curLineLen++
+					curLineLen += uint(RuneWidth(char))
 					wordBuf = ""
 					wordBufLen = 0
 					if curLineLen == this.Limit {
@@ -238,38 +266,41 @@ func (this *Wrapper) Wrap(s string) string {
 				} else {
 					_wrapDebug("\n>> HERE IN SPACE ADD\n\n")
 					lines[curLineNum] += string(char)
-					curLineLen++
+					curLineLen += uint(RuneWidth(char))
 				}
 			} else {
 				_wrapDebug(">> ADD CHAR '%c'\n", char)
+				charLen := uint(runeWidthAfter(char, lastChar))
 				totalLineLen := curLineLen + wordBufLen
-				if totalLineLen+1 > this.Limit {
+				if charLen > 0 && totalLineLen+charLen > this.Limit {
 					if curLineLen > 0 { // has prefix before current word
 						_wrapDebug("\n>> FINISH LINE WITH WORDBUF \"%s\"\n", wordBuf)
 						finishLine("")
 						wordBuf += string(char)
-						wordBufLen++
+						wordBufLen += charLen
 
 					} else { // the word itself is longer than line
 						_wrapDebug("\n>> WORD IS BIGGER \"%s\" (%v)\n", wordBuf, this.BreakWords)
-						if this.BreakWords {
+						if this.BreakWords && wordBufLen > 0 {
+							commitWordControlChars()
 							finishLine(wordBuf)
 							wordBuf = string(char)
-							wordBufLen = 1
+							wordBufLen = charLen
 						} else {
 							wordBuf += string(char)
-							wordBufLen++
+							wordBufLen += charLen
 						}
 					}
 				} else {
 					wordBuf += string(char)
-					wordBufLen++
+					wordBufLen += charLen
 				}
 			}
 		}
 		lastChar = char
 	}
 	if wordBufLen > 0 {
+		commitWordControlChars()
 		lines[curLineNum] += string(wordBuf)
 	}
 	if controlCharNoneEndBuf.Len() > 0 {
@@ -293,6 +324,36 @@ func (this *Wrapper) Wrap(s string) string {
 	return rendered
 }
 
+// WrapTokens wraps strings containing formatter style tokens (eg `<info>`),
+// which do not count towards the line length. Styles which are open at a line
+// break are closed with `<reset>` at the end of the line and re-opened at the
+// start of the next line. Only tokens known in `Styles` are considered.
+func (this *Wrapper) WrapTokens(s string) string {
+	styles := this.Styles
+	if styles == nil {
+		styles = DefaultStyles
+	}
+	tokens := make([]string, 0)
+	for token, _ := range styles {
+		if token != "reset" && strings.Index(token, "/") != 0 {
+			tokens = append(tokens, token)
+		}
+	}
+	sort.Strings(tokens)
+
+	// map tokens to (otherwise unused) control characters the wrapper understands
+	toCtrl := []string{`\<`, "\uE000", "<reset>", "\033[0m"}
+	fromCtrl := []string{"\uE000", `\<`, "\033[0m", "<reset>"}
+	for idx, token := range tokens {
+		ctrl := fmt.Sprintf("\033[%dm", 1000+idx)
+		toCtrl = append(toCtrl, "<"+token+">", ctrl, "</"+token+">", "\033[0m")
+		fromCtrl = append(fromCtrl, ctrl, "<"+token+">")
+	}
+
+	wrapped := this.Wrap(strings.NewReplacer(toCtrl...).Replace(s))
+	return strings.NewReplacer(fromCtrl...).Replace(wrapped)
+}
+
 func _wrapDebug(str string, args ...interface{}) {
 	if dbg := os.Getenv("WRAP_DEBUG"); dbg == "yes" || dbg == "1" {
 		fmt.Printf(str, args...)
@@ -96,6 +96,14 @@ var testsWrapText = []struct{
 		from: "\033[34mfoobarbazfoobarbazfoobarbazfoobarbaz",
 		expect: "\033[34mfoobarbazfoo\033[0m\n\033[34mbarbazfoobar\033[0m\n\033[34mbazfoobarbaz\033[0m",
 	},
+	{
+		from: "foo bar baz (\033[34mbar\033[0m)",
+		expect: "foo bar baz\n(\033[34mbar\033[0m)",
+	},
+	{
+		from: "foo bar (\033[34mbar baz\033[0m)",
+		expect: "foo bar (\033[34mbar\033[0m\n\033[34mbaz\033[0m)",
+	},
 }
 
 func TestWrapText(t *testing.T) {
@@ -149,4 +157,43 @@ func TestWrapTextTrim(t *testing.T) {
 			So(wrapped, ShouldEqual, expect)
 		})
 	})
-}
\ No newline at end of file
+}
+
+func TestWrapWideRunes(t *testing.T) {
+	Convey("Wrapping wide runes", t, func() {
+		So(Wrap("日本語 テキスト foo", 8), ShouldEqual, "日本語\nテキスト\nfoo")
+
+		Convey("Breaking words by display width", func() {
+			wrapper := NewWrapper(6)
+			wrapper.BreakWords = true
+			So(wrapper.Wrap("日本語のテキスト"), ShouldEqual, "日本語\nのテキ\nスト")
+			So(wrapper.Wrap("\033[31m日本語のテキスト\033[0m"), ShouldEqual,
+				"\033[31m日本語\033[0m\n\033[31mのテキ\033[0m\n\033[31mスト\033[0m")
+		})
+
+		Convey("Not breaking joined emoji", func() {
+			wrapper := NewWrapper(3)
+			wrapper.BreakWords = true
+			So(wrapper.Wrap("👨\u200D👩\u200D👧👍"), ShouldEqual, "👨\u200D👩\u200D👧\n👍")
+		})
+
+		Convey("Re-opening styles", func() {
+			So(NewWrapper(10).WrapTokens("<info>漢字 漢字 漢字 漢字<reset>"), ShouldEqual,
+				"<info>漢字 漢字<reset>\n<info>漢字 漢字<reset>")
+		})
+	})
+}
+
+func TestWrapTokensStyles(t *testing.T) {
+	Convey("Wrapping tokens of custom styles", t, func() {
+		wrapper := NewWrapper(9)
+		wrapper.Styles = map[string]string{"accent": "A>", "reset": "R>"}
+		So(wrapper.WrapTokens("<accent>aaaa bbbb cccc<reset> dd"), ShouldEqual,
+			"<accent>aaaa bbbb<reset>\n<accent>cccc<reset> dd")
+
+		Convey("Unknown tokens count towards the width", func() {
+			So(NewWrapper(9).WrapTokens("<accent>aaaa bbbb cccc<reset> dd"), ShouldEqual,
+				"<accent>aaaa\nbbbb cccc<reset>\ndd")
+		})
+	})
+}